@@ -2,21 +2,55 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"os"
 	"os/signal"
+	"strings"
+	"syscall"
 
 	toolboxmcp "github.com/krubenok/toolbox/internal/mcp"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
+// tokenEnv names the environment variable holding the bearer token for --http.
+const tokenEnv = "TOOLBOX_MCP_TOKEN"
+
 func main() {
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	httpAddr := flag.String("http", "", "Serve streamable HTTP on this address (e.g. :8080) instead of stdio")
+	tokenFile := flag.String("token-file", "", "File holding the bearer token HTTP clients must send (default: $"+tokenEnv+")")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	server := toolboxmcp.NewServer()
+	server := toolboxmcp.NewServer(ctx)
+
+	if *httpAddr == "" {
+		if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil {
+			log.Fatalf("Server error: %v", err)
+		}
+		return
+	}
+
+	token := os.Getenv(tokenEnv)
+	if *tokenFile != "" {
+		data, err := os.ReadFile(*tokenFile)
+		if err != nil {
+			log.Fatalf("Read token file: %v", err)
+		}
+		token = strings.TrimSpace(string(data))
+		if token == "" {
+			log.Fatalf("Token file %s is empty", *tokenFile)
+		}
+	}
 
-	if err := server.Run(ctx, &mcp.StdioTransport{}); err != nil {
+	err := toolboxmcp.ServeHTTP(ctx, server, toolboxmcp.HTTPOptions{
+		Addr:  *httpAddr,
+		Token: token,
+		Logf:  log.Printf,
+	})
+	if err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
 }
@@ -0,0 +1,317 @@
+// Package ado provides a shared Azure DevOps REST client used by the toolbox tools.
+package ado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+const (
+	defaultHTTPTimeout = 30 * time.Second
+
+	// DefaultBaseURL is the Azure DevOps Services base URL.
+	DefaultBaseURL = "https://dev.azure.com"
+
+	// maxErrorBody caps how much of an error response body is kept in HTTPError.
+	maxErrorBody = 64 * 1024
+)
+
+// HTTPError represents a non-2xx response from the Azure DevOps API.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	URL        string
+	Body       string
+	Header     http.Header
+}
+
+func (e *HTTPError) Error() string {
+	if e == nil {
+		return "http error"
+	}
+	if e.Body == "" {
+		return fmt.Sprintf("request failed (%s): %s", e.Status, e.URL)
+	}
+	return fmt.Sprintf("request failed (%s): %s: %s", e.Status, e.URL, e.Body)
+}
+
+// IsStatus reports whether err is an HTTPError with the given status code.
+func IsStatus(err error, statusCode int) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == statusCode
+}
+
+// RawBody receives an undecoded response body when passed as the result of Do or GetJSON,
+// for binary downloads such as attachments.
+type RawBody struct {
+	// Limit caps how many bytes are read (0 = no limit).
+	Limit int64
+
+	Data        []byte
+	ContentType string
+	// Truncated is set when the body was longer than Limit; Data then holds the first Limit bytes.
+	Truncated bool
+}
+
+// Client handles Azure DevOps API requests.
+type Client struct {
+	auth       *auth.Auth
+	baseURL    string
+	httpClient *http.Client
+	retry      RetryPolicy
+	sleep      func(context.Context, time.Duration) error
+	debug      bool
+	debugLog   func(string)
+}
+
+// NewClient creates a new ADO API client.
+// An empty baseURL falls back to DefaultBaseURL.
+func NewClient(azAuth *auth.Auth, baseURL string, debug bool, debugLog func(string)) *Client {
+	return &Client{
+		auth:       azAuth,
+		baseURL:    NormalizeBaseURL(baseURL),
+		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
+		retry:      DefaultRetryPolicy(),
+		sleep:      sleepContext,
+		debug:      debug,
+		debugLog:   debugLog,
+	}
+}
+
+// NormalizeBaseURL trims trailing slashes and falls back to DefaultBaseURL when empty.
+func NormalizeBaseURL(baseURL string) string {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if baseURL == "" {
+		return DefaultBaseURL
+	}
+	return baseURL
+}
+
+// BaseURL returns the base URL the client sends requests to.
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// SetTransport replaces the HTTP transport used by the client (primarily for tests).
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// SetRetryPolicy replaces the retry policy used for throttled or unavailable responses.
+func (c *Client) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// Debugf writes a debug line when debug output is enabled.
+func (c *Client) Debugf(format string, args ...any) {
+	if c.debug && c.debugLog != nil {
+		c.debugLog(fmt.Sprintf(format, args...))
+	}
+}
+
+// URL builds an API URL of the form {baseURL}/{org}/{project}/_apis/{path}?api-version={apiVersion}.
+// The org and project are path-escaped; path must already be escaped by the caller.
+// An empty project produces an organization-scoped URL. Extra query values are appended after api-version.
+func (c *Client) URL(org, project, path, apiVersion string, query url.Values) string {
+	return c.TeamURL(org, project, "", path, apiVersion, query)
+}
+
+// TeamURL is like URL but inserts a team segment after the project, for team-scoped
+// APIs such as WIQL queries that use @CurrentIteration. An empty team behaves like URL.
+func (c *Client) TeamURL(org, project, team, path, apiVersion string, query url.Values) string {
+	var b strings.Builder
+	b.WriteString(c.baseURL)
+	for _, segment := range []string{org, project, team} {
+		if segment == "" {
+			continue
+		}
+		b.WriteString("/")
+		b.WriteString(url.PathEscape(segment))
+	}
+	b.WriteString("/_apis/")
+	b.WriteString(strings.TrimLeft(path, "/"))
+	b.WriteString("?api-version=")
+	b.WriteString(url.QueryEscape(apiVersion))
+	if len(query) > 0 {
+		b.WriteString("&")
+		b.WriteString(query.Encode())
+	}
+	return b.String()
+}
+
+// GetJSON performs a GET request and decodes the JSON response into result.
+func (c *Client) GetJSON(ctx context.Context, apiURL string, result any) error {
+	return c.Do(ctx, http.MethodGet, apiURL, "", nil, result)
+}
+
+// SendJSON marshals body as JSON, sends it with the given method and content type,
+// and decodes the JSON response into result. An empty contentType defaults to application/json.
+func (c *Client) SendJSON(ctx context.Context, method, apiURL, contentType string, body, result any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode request body: %w", err)
+	}
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	return c.Do(ctx, method, apiURL, contentType, payload, result)
+}
+
+// Do sends a request with an optional body and decodes the JSON response into result.
+// A nil result discards the response body; a *RawBody result receives it undecoded. Non-2xx responses are returned as *HTTPError.
+// Throttled (429) responses, and unavailable (503) and gateway errors on GET, are retried
+// with jittered exponential backoff, honoring Retry-After and X-RateLimit-Reset up to
+// maxServerDelayFactor * MaxDelay, for as long as ctx allows.
+// A 401 with renewable credentials renews them and retries the request once.
+func (c *Client) Do(ctx context.Context, method, apiURL, contentType string, body []byte, result any) error {
+	renewed := false
+	for attempt := 0; ; attempt++ {
+		err := c.doOnce(ctx, method, apiURL, contentType, body, result)
+
+		var httpErr *HTTPError
+		if err == nil || !errors.As(err, &httpErr) {
+			return err
+		}
+		if httpErr.StatusCode == http.StatusUnauthorized && !renewed && c.auth.Renew != nil {
+			renewed = true
+			c.Debugf("Request unauthorized; renewing credentials and retrying %s", apiURL)
+			if renewErr := c.renewAuth(); renewErr != nil {
+				c.Debugf("Renewing credentials failed: %v", renewErr)
+				return err
+			}
+			attempt--
+			continue
+		}
+		if attempt >= c.retry.MaxRetries || !isRetryableStatus(method, httpErr.StatusCode) {
+			return err
+		}
+
+		delay, fromServer := throttleDelay(httpErr.Header, time.Now())
+		if !fromServer {
+			delay = c.retry.backoff(attempt)
+		} else if limit := c.retry.maxServerDelay(); delay > limit {
+			c.Debugf("Not retrying %s: server asked to wait %s, more than the %s limit", apiURL, delay, limit)
+			return err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			c.Debugf("Not retrying %s: wait of %s exceeds context deadline", apiURL, delay)
+			return err
+		}
+
+		source := "backoff"
+		if fromServer {
+			source = "server hint"
+		}
+		c.Debugf("Retrying %s %s in %s (%s, attempt %d/%d, %s)", method, apiURL, delay.Round(time.Millisecond), source, attempt+1, c.retry.MaxRetries, httpErr.Status)
+
+		if err := c.sleep(ctx, delay); err != nil {
+			return httpErr
+		}
+	}
+}
+
+// doOnce performs a single HTTP round trip.
+func (c *Client) doOnce(ctx context.Context, method, apiURL, contentType string, body []byte, result any) error {
+	if method == http.MethodGet {
+		c.Debugf("Fetching: %s", apiURL)
+	} else {
+		c.Debugf("Sending %s: %s", method, apiURL)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reader)
+	if err != nil {
+		return err
+	}
+
+	raw, isRaw := result.(*RawBody)
+
+	if c.auth.Renew != nil && c.auth.ExpiresSoon(time.Now()) {
+		c.Debugf("Token expires at %s; renewing", c.auth.ExpiresOn.Format(time.RFC3339))
+		if err := c.renewAuth(); err != nil {
+			c.Debugf("Renewing credentials failed, using current token: %v", err)
+		}
+	}
+	req.Header.Set("Authorization", c.auth.AuthorizationHeader())
+	if isRaw {
+		req.Header.Set("Accept", "*/*")
+	} else {
+		req.Header.Set("Accept", "application/json")
+	}
+	if body != nil && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if summary := rateLimitSummary(resp.Header); summary != "" {
+		c.Debugf("Rate limit: %s", summary)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &HTTPError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			URL:        apiURL,
+			Body:       strings.TrimSpace(string(bodyBytes)),
+			Header:     resp.Header,
+		}
+	}
+
+	if result == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if isRaw {
+		return readRaw(resp, raw)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// renewAuth replaces the client's credentials with freshly fetched ones.
+func (c *Client) renewAuth() error {
+	renewed, err := c.auth.Renew()
+	if err != nil {
+		return err
+	}
+	c.auth = renewed
+	return nil
+}
+
+// readRaw reads the response body into raw, honoring raw.Limit.
+func readRaw(resp *http.Response, raw *RawBody) error {
+	reader := io.Reader(resp.Body)
+	if raw.Limit > 0 {
+		reader = io.LimitReader(resp.Body, raw.Limit+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return err
+	}
+	raw.Truncated = raw.Limit > 0 && int64(len(data)) > raw.Limit
+	if raw.Truncated {
+		data = data[:raw.Limit]
+	}
+	raw.Data = data
+	raw.ContentType = resp.Header.Get("Content-Type")
+	return nil
+}
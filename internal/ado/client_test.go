@@ -0,0 +1,220 @@
+package ado
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func newResponse(r *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}
+}
+
+func TestClientURL(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(&auth.Auth{Scheme: "Basic", Token: "x"}, "https://example.test/", false, nil)
+
+	tests := []struct {
+		name    string
+		org     string
+		project string
+		path    string
+		query   url.Values
+		want    string
+	}{
+		{
+			name:    "project scoped",
+			org:     "org",
+			project: "my project",
+			path:    "wit/workitems/1",
+			want:    "https://example.test/org/my%20project/_apis/wit/workitems/1?api-version=7.1",
+		},
+		{
+			name: "org scoped with query",
+			org:  "org",
+			path: "/git/pullrequests",
+			query: url.Values{
+				"$top": {"5"},
+			},
+			want: "https://example.test/org/_apis/git/pullrequests?api-version=7.1&%24top=5",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := c.URL(tt.org, tt.project, tt.path, "7.1", tt.query)
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClientDo(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends auth and decodes JSON", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Bearer", Token: "tok"}, "", false, nil)
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+				t.Fatalf("Authorization = %q, want %q", got, "Bearer tok")
+			}
+			return newResponse(r, http.StatusOK, `{"id":42}`), nil
+		}))
+
+		var got struct {
+			ID int `json:"id"`
+		}
+		if err := c.GetJSON(context.Background(), "https://example.test/x", &got); err != nil {
+			t.Fatalf("GetJSON: %v", err)
+		}
+		if got.ID != 42 {
+			t.Fatalf("id = %d, want 42", got.ID)
+		}
+	})
+
+	t.Run("sends JSON body with content type", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method != http.MethodPost {
+				t.Fatalf("method = %s, want POST", r.Method)
+			}
+			if got := r.Header.Get("Content-Type"); got != "application/json-patch+json" {
+				t.Fatalf("Content-Type = %q", got)
+			}
+			b, _ := io.ReadAll(r.Body)
+			if string(b) != `{"a":1}` {
+				t.Fatalf("body = %s", b)
+			}
+			return newResponse(r, http.StatusOK, `{}`), nil
+		}))
+
+		err := c.SendJSON(context.Background(), http.MethodPost, "https://example.test/x", "application/json-patch+json", map[string]int{"a": 1}, nil)
+		if err != nil {
+			t.Fatalf("SendJSON: %v", err)
+		}
+	})
+
+	t.Run("raw body is read undecoded up to the limit", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if got := r.Header.Get("Accept"); got != "*/*" {
+				t.Fatalf("Accept = %q, want */*", got)
+			}
+			resp := newResponse(r, http.StatusOK, "hello world")
+			resp.Header.Set("Content-Type", "text/plain")
+			return resp, nil
+		}))
+
+		full := &RawBody{}
+		if err := c.GetJSON(context.Background(), "https://example.test/x", full); err != nil {
+			t.Fatalf("GetJSON: %v", err)
+		}
+		if string(full.Data) != "hello world" || full.Truncated || full.ContentType != "text/plain" {
+			t.Fatalf("got %+v", full)
+		}
+
+		limited := &RawBody{Limit: 5}
+		if err := c.GetJSON(context.Background(), "https://example.test/x", limited); err != nil {
+			t.Fatalf("GetJSON: %v", err)
+		}
+		if string(limited.Data) != "hello" || !limited.Truncated {
+			t.Fatalf("got %+v", limited)
+		}
+	})
+
+	t.Run("non-2xx returns HTTPError", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return newResponse(r, http.StatusNotFound, ` {"message":"nope"} `), nil
+		}))
+
+		err := c.GetJSON(context.Background(), "https://example.test/x", &struct{}{})
+		var httpErr *HTTPError
+		if !errors.As(err, &httpErr) {
+			t.Fatalf("err = %v, want *HTTPError", err)
+		}
+		if httpErr.Body != `{"message":"nope"}` {
+			t.Fatalf("body = %q", httpErr.Body)
+		}
+		if !IsStatus(err, http.StatusNotFound) {
+			t.Fatalf("IsStatus(404) = false, want true")
+		}
+	})
+
+	t.Run("401 renews credentials and retries once", func(t *testing.T) {
+		t.Parallel()
+
+		renewals := 0
+		var renew func() (*auth.Auth, error)
+		renew = func() (*auth.Auth, error) {
+			renewals++
+			return &auth.Auth{Scheme: "Bearer", Token: "fresh", Renew: renew}, nil
+		}
+
+		c := NewClient(&auth.Auth{Scheme: "Bearer", Token: "stale", Renew: renew}, "", false, nil)
+		var seen []string
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			seen = append(seen, r.Header.Get("Authorization"))
+			return newResponse(r, http.StatusUnauthorized, ""), nil
+		}))
+
+		err := c.GetJSON(context.Background(), "https://example.test/x", &struct{}{})
+		if !IsStatus(err, http.StatusUnauthorized) {
+			t.Fatalf("err = %v, want 401", err)
+		}
+		if renewals != 1 {
+			t.Fatalf("renewals = %d, want 1", renewals)
+		}
+		if want := []string{"Bearer stale", "Bearer fresh"}; strings.Join(seen, ",") != strings.Join(want, ",") {
+			t.Fatalf("Authorization headers = %q, want %q", seen, want)
+		}
+	})
+
+	t.Run("token about to expire is renewed before sending", func(t *testing.T) {
+		t.Parallel()
+
+		renew := func() (*auth.Auth, error) {
+			return &auth.Auth{Scheme: "Bearer", Token: "fresh", ExpiresOn: time.Now().Add(time.Hour)}, nil
+		}
+		c := NewClient(&auth.Auth{Scheme: "Bearer", Token: "stale", ExpiresOn: time.Now().Add(time.Minute), Renew: renew}, "", false, nil)
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if got := r.Header.Get("Authorization"); got != "Bearer fresh" {
+				t.Fatalf("Authorization = %q, want %q", got, "Bearer fresh")
+			}
+			return newResponse(r, http.StatusOK, `{}`), nil
+		}))
+
+		if err := c.GetJSON(context.Background(), "https://example.test/x", &struct{}{}); err != nil {
+			t.Fatalf("GetJSON: %v", err)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package ado
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/krubenok/toolbox/internal/auth"
+	"github.com/krubenok/toolbox/internal/config"
+)
+
+const configFile = "ado.json"
+
+// Config holds settings shared by every Azure DevOps tool.
+type Config struct {
+	Retry *RetryConfig `json:"retry,omitempty"`
+	// Hosts lists Azure DevOps Server (on-premises) hosts whose URLs the tools accept.
+	Hosts []HostConfig `json:"hosts,omitempty"`
+}
+
+// RetryConfig controls how throttled or temporarily unavailable requests are retried.
+// Zero values fall back to the defaults from DefaultRetryConfig.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt. Set to -1 to disable retries.
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// InitialDelayMs is the base backoff delay before the first retry.
+	InitialDelayMs int `json:"initialDelayMs,omitempty"`
+	// MaxDelayMs caps the computed exponential backoff. A server-requested wait (Retry-After)
+	// longer than four times this value is not waited out; the request fails instead.
+	MaxDelayMs int `json:"maxDelayMs,omitempty"`
+}
+
+// DefaultRetryConfig returns the default retry config.
+func DefaultRetryConfig() *RetryConfig {
+	return &RetryConfig{
+		MaxRetries:     4,
+		InitialDelayMs: 500,
+		MaxDelayMs:     30000,
+	}
+}
+
+// LoadConfig loads the shared ADO config from ~/.toolbox/ado.json. The repository's
+// .toolbox directory is ignored: hosts decide where credentials are sent, so a cloned
+// repository must not be able to add them. Falls back to defaults if it doesn't exist.
+func LoadConfig() (*Config, error) {
+	var cfg Config
+	err := config.LoadUserForOrg("", configFile, &cfg)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{Retry: DefaultRetryConfig()}, nil
+		}
+		return nil, err
+	}
+
+	if cfg.Retry == nil {
+		cfg.Retry = DefaultRetryConfig()
+	}
+
+	return &cfg, nil
+}
+
+// NewConfiguredClient creates a client with settings from ~/.toolbox/ado.json applied.
+func NewConfiguredClient(azAuth *auth.Auth, baseURL string, debug bool, debugLog func(string)) (*Client, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("load ado config: %w", err)
+	}
+	c := NewClient(azAuth, baseURL, debug, debugLog)
+	c.SetRetryPolicy(cfg.Retry.Policy())
+	return c, nil
+}
+
+// Policy converts the config into a RetryPolicy, filling unset values with defaults.
+func (rc *RetryConfig) Policy() RetryPolicy {
+	def := DefaultRetryConfig()
+	if rc == nil {
+		rc = def
+	}
+
+	maxRetries := rc.MaxRetries
+	switch {
+	case maxRetries < 0:
+		maxRetries = 0
+	case maxRetries == 0:
+		maxRetries = def.MaxRetries
+	}
+	initial := rc.InitialDelayMs
+	if initial <= 0 {
+		initial = def.InitialDelayMs
+	}
+	maxDelay := rc.MaxDelayMs
+	if maxDelay <= 0 {
+		maxDelay = def.MaxDelayMs
+	}
+
+	return RetryPolicy{
+		MaxRetries:   maxRetries,
+		InitialDelay: time.Duration(initial) * time.Millisecond,
+		MaxDelay:     time.Duration(maxDelay) * time.Millisecond,
+	}
+}
@@ -0,0 +1,38 @@
+package ado
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/config"
+)
+
+func TestLoadConfigIgnoresRepoHosts(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(config.ProfileEnv, "")
+
+	repo := t.TempDir()
+	dir := filepath.Join(repo, ".toolbox")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"hosts": [{"host": "attacker.example", "pathPrefix": "x"}], "retry": {"maxRetries": 9}}`
+	if err := os.WriteFile(filepath.Join(dir, configFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(repo)
+
+	cfg, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.Hosts) != 0 {
+		t.Fatalf("hosts = %+v, want none from the repository", cfg.Hosts)
+	}
+	if cfg.Retry.MaxRetries != DefaultRetryConfig().MaxRetries {
+		t.Fatalf("retry = %+v, want defaults", cfg.Retry)
+	}
+}
@@ -0,0 +1,133 @@
+package ado
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxServerDelayFactor bounds a server-requested wait (Retry-After or X-RateLimit-Reset)
+// to this multiple of MaxDelay. Longer waits give up instead of hanging the command.
+const maxServerDelayFactor = 4
+
+// RetryPolicy describes how many times and how long to wait between retries.
+type RetryPolicy struct {
+	MaxRetries   int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured.
+func DefaultRetryPolicy() RetryPolicy {
+	return DefaultRetryConfig().Policy()
+}
+
+// isRetryableStatus reports whether a response status should be retried.
+// 429 means the request was rejected before it was processed, so it is safe to retry for
+// any method. 503 and gateway errors may come after a write has landed, so they are only
+// retried for idempotent reads; retrying a POST could post a comment twice.
+func isRetryableStatus(method string, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return method == http.MethodGet
+	}
+	return false
+}
+
+// maxServerDelay returns the longest server-requested wait the policy accepts.
+func (p RetryPolicy) maxServerDelay() time.Duration {
+	maxDelay := p.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryPolicy().MaxDelay
+	}
+	return maxServerDelayFactor * maxDelay
+}
+
+// backoff returns the jittered exponential delay for the given retry attempt (0-based).
+// The delay is drawn from [d/2, d] where d = InitialDelay * 2^attempt, capped at MaxDelay.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialDelay
+	for i := 0; i < attempt && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
+
+// throttleDelay returns the server-requested delay from Retry-After or X-RateLimit-Reset.
+// The second return value is false when the response carries no usable hint.
+func throttleDelay(h http.Header, now time.Time) (time.Duration, bool) {
+	if d, ok := parseRetryAfter(h.Get("Retry-After"), now); ok {
+		return d, true
+	}
+	// X-RateLimit-Reset is a Unix timestamp (seconds) when the throttling window resets.
+	if v := strings.TrimSpace(h.Get("X-RateLimit-Reset")); v != "" {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			d := time.Unix(secs, 0).Sub(now)
+			if d < 0 {
+				d = 0
+			}
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// parseRetryAfter parses a Retry-After header in either delta-seconds or HTTP-date form.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			secs = 0
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// rateLimitSummary formats the X-RateLimit-* headers for debug output.
+func rateLimitSummary(h http.Header) string {
+	var parts []string
+	for _, name := range []string{"X-RateLimit-Resource", "X-RateLimit-Delay", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"} {
+		if v := h.Get(name); v != "" {
+			parts = append(parts, strings.TrimPrefix(name, "X-RateLimit-")+"="+v)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,202 @@
+package ado
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		in     string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "seconds", in: "3", want: 3 * time.Second, wantOK: true},
+		{name: "http date", in: "Wed, 01 Jan 2025 00:00:10 GMT", want: 10 * time.Second, wantOK: true},
+		{name: "past date clamps to zero", in: "Tue, 31 Dec 2024 23:59:00 GMT", want: 0, wantOK: true},
+		{name: "empty", in: "", wantOK: false},
+		{name: "garbage", in: "soon", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := parseRetryAfter(tt.in, now)
+			if ok != tt.wantOK || got != tt.want {
+				t.Fatalf("got (%s, %v), want (%s, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	p := RetryPolicy{MaxRetries: 5, InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for attempt, ceiling := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		ceiling *= time.Millisecond
+		got := p.backoff(attempt)
+		if got < ceiling/2 || got > ceiling {
+			t.Fatalf("attempt %d: backoff %s outside [%s, %s]", attempt, got, ceiling/2, ceiling)
+		}
+	}
+}
+
+func TestClientRetriesThrottledRequests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries 429 honoring Retry-After", func(t *testing.T) {
+		t.Parallel()
+
+		var logs []string
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", true, func(s string) { logs = append(logs, s) })
+		var slept []time.Duration
+		c.sleep = func(_ context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			return nil
+		}
+
+		calls := 0
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				resp := newResponse(r, http.StatusTooManyRequests, "")
+				resp.Header.Set("Retry-After", "2")
+				resp.Header.Set("X-RateLimit-Resource", "Core")
+				return resp, nil
+			}
+			return newResponse(r, http.StatusOK, `{}`), nil
+		}))
+
+		if err := c.GetJSON(context.Background(), "https://example.test/x", &struct{}{}); err != nil {
+			t.Fatalf("GetJSON: %v", err)
+		}
+		if calls != 2 {
+			t.Fatalf("calls = %d, want 2", calls)
+		}
+		if len(slept) != 1 || slept[0] != 2*time.Second {
+			t.Fatalf("slept = %v, want [2s]", slept)
+		}
+		if len(logs) == 0 {
+			t.Fatalf("expected debug output for retry")
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		c.SetRetryPolicy(RetryPolicy{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: time.Millisecond})
+		c.sleep = func(context.Context, time.Duration) error { return nil }
+
+		calls := 0
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return newResponse(r, http.StatusServiceUnavailable, ""), nil
+		}))
+
+		err := c.GetJSON(context.Background(), "https://example.test/x", &struct{}{})
+		if !IsStatus(err, http.StatusServiceUnavailable) {
+			t.Fatalf("err = %v, want 503 HTTPError", err)
+		}
+		if calls != 3 {
+			t.Fatalf("calls = %d, want 3", calls)
+		}
+	})
+
+	t.Run("does not retry non-retryable status", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		calls := 0
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return newResponse(r, http.StatusBadRequest, ""), nil
+		}))
+
+		_ = c.GetJSON(context.Background(), "https://example.test/x", &struct{}{})
+		if calls != 1 {
+			t.Fatalf("calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("does not retry 503 for writes", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		c.sleep = func(context.Context, time.Duration) error { return nil }
+		calls := 0
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			return newResponse(r, http.StatusServiceUnavailable, ""), nil
+		}))
+
+		err := c.SendJSON(context.Background(), http.MethodPost, "https://example.test/x", "", map[string]string{}, nil)
+		if !IsStatus(err, http.StatusServiceUnavailable) {
+			t.Fatalf("err = %v, want 503 HTTPError", err)
+		}
+		if calls != 1 {
+			t.Fatalf("calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("gives up when server wait exceeds limit", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		c.SetRetryPolicy(RetryPolicy{MaxRetries: 2, InitialDelay: time.Millisecond, MaxDelay: time.Second})
+		var slept []time.Duration
+		c.sleep = func(_ context.Context, d time.Duration) error {
+			slept = append(slept, d)
+			return nil
+		}
+		calls := 0
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			resp := newResponse(r, http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", "3600")
+			return resp, nil
+		}))
+
+		err := c.GetJSON(context.Background(), "https://example.test/x", &struct{}{})
+		if !IsStatus(err, http.StatusTooManyRequests) {
+			t.Fatalf("err = %v, want 429 HTTPError", err)
+		}
+		if calls != 1 || len(slept) != 0 {
+			t.Fatalf("calls = %d, slept = %v, want 1 call and no wait", calls, slept)
+		}
+	})
+
+	t.Run("stops when wait exceeds context deadline", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+		calls := 0
+		c.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			calls++
+			resp := newResponse(r, http.StatusTooManyRequests, "")
+			resp.Header.Set("Retry-After", "60")
+			return resp, nil
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err := c.GetJSON(ctx, "https://example.test/x", &struct{}{})
+		if !IsStatus(err, http.StatusTooManyRequests) {
+			t.Fatalf("err = %v, want 429 HTTPError", err)
+		}
+		if calls != 1 {
+			t.Fatalf("calls = %d, want 1", calls)
+		}
+	})
+}
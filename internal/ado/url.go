@@ -0,0 +1,108 @@
+package ado
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// HostConfig declares an Azure DevOps Server (on-premises) host. URLs on the host have the
+// form {scheme}://{host}/{pathPrefix}/{collection}/{project}/..., where the collection plays
+// the role of the organization on Azure DevOps Services.
+type HostConfig struct {
+	// Host is the host name, with the port if it is not the default (e.g. "tfs.corp.example:8080").
+	Host string `json:"host"`
+	// PathPrefix is the virtual directory before the collection segment (e.g. "tfs"). Empty when
+	// collections are served from the root of the host.
+	PathPrefix string `json:"pathPrefix,omitempty"`
+}
+
+// Server identifies the Azure DevOps server and organization (or collection) a URL points at.
+type Server struct {
+	// BaseURL is the URL API paths are built on, e.g. "https://dev.azure.com" or
+	// "https://tfs.corp.example/tfs".
+	BaseURL string
+	// Organization is the organization, or the collection on Azure DevOps Server.
+	Organization string
+}
+
+// ParseURL splits an Azure DevOps web URL into its server and the decoded path segments
+// following the organization, using the hosts configured in ~/.toolbox/ado.json.
+func ParseURL(rawURL string) (Server, []string, error) {
+	cfg, err := LoadConfig()
+	if err != nil {
+		return Server{}, nil, fmt.Errorf("load ado config: %w", err)
+	}
+	return SplitURL(rawURL, cfg.Hosts)
+}
+
+// SplitURL is like ParseURL but takes the configured on-premises hosts explicitly.
+// dev.azure.com and {org}.visualstudio.com URLs are always recognized; both map to the
+// dev.azure.com base URL.
+func SplitURL(rawURL string, hosts []HostConfig) (Server, []string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return Server{}, nil, fmt.Errorf("invalid URL: %s", rawURL)
+	}
+
+	parts := SplitPath(u.Path)
+	host := strings.ToLower(u.Host)
+
+	if host == "dev.azure.com" {
+		// Expected: [org, ...]
+		if len(parts) < 1 {
+			return Server{}, nil, fmt.Errorf("URL has no organization: %s", rawURL)
+		}
+		return Server{BaseURL: DefaultBaseURL, Organization: parts[0]}, parts[1:], nil
+	}
+
+	if strings.HasSuffix(host, ".visualstudio.com") {
+		// Expected: [DefaultCollection?, ...]
+		if len(parts) > 0 && strings.EqualFold(parts[0], "DefaultCollection") {
+			parts = parts[1:]
+		}
+		org := strings.Split(host, ".")[0]
+		return Server{BaseURL: DefaultBaseURL, Organization: org}, parts, nil
+	}
+
+	for _, h := range hosts {
+		if !strings.EqualFold(strings.TrimSpace(h.Host), u.Host) {
+			continue
+		}
+
+		// Expected: [prefix..., collection, ...]
+		prefix := SplitPath(h.PathPrefix)
+		if len(parts) <= len(prefix) {
+			return Server{}, nil, fmt.Errorf("URL has no collection after /%s: %s", strings.Join(prefix, "/"), rawURL)
+		}
+		for i, p := range prefix {
+			if !strings.EqualFold(parts[i], p) {
+				return Server{}, nil, fmt.Errorf("URL path does not start with configured prefix /%s: %s", strings.Join(prefix, "/"), rawURL)
+			}
+		}
+
+		base := u.Scheme + "://" + u.Host
+		for _, p := range parts[:len(prefix)] {
+			base += "/" + url.PathEscape(p)
+		}
+		return Server{BaseURL: base, Organization: parts[len(prefix)]}, parts[len(prefix)+1:], nil
+	}
+
+	return Server{}, nil, fmt.Errorf("unsupported Azure DevOps host: %s (add Azure DevOps Server hosts to \"hosts\" in ~/.toolbox/ado.json)", host)
+}
+
+// SplitPath splits a URL path into decoded segments, skipping empty ones.
+func SplitPath(path string) []string {
+	var parts []string
+	for _, p := range strings.Split(path, "/") {
+		if p == "" {
+			continue
+		}
+		decoded, err := url.PathUnescape(p)
+		if err != nil {
+			decoded = p
+		}
+		parts = append(parts, decoded)
+	}
+	return parts
+}
@@ -0,0 +1,74 @@
+package ado
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitURL(t *testing.T) {
+	t.Parallel()
+
+	hosts := []HostConfig{
+		{Host: "tfs.corp.example", PathPrefix: "tfs"},
+		{Host: "ado.corp.example:8080"},
+	}
+
+	tests := []struct {
+		name     string
+		rawURL   string
+		want     Server
+		wantRest []string
+		wantErr  bool
+	}{
+		{
+			name:     "dev.azure.com",
+			rawURL:   "https://dev.azure.com/org/my%20project/_git/repo",
+			want:     Server{BaseURL: DefaultBaseURL, Organization: "org"},
+			wantRest: []string{"my project", "_git", "repo"},
+		},
+		{
+			name:     "visualstudio.com with DefaultCollection",
+			rawURL:   "https://org.visualstudio.com/DefaultCollection/project/_workitems/edit/1",
+			want:     Server{BaseURL: DefaultBaseURL, Organization: "org"},
+			wantRest: []string{"project", "_workitems", "edit", "1"},
+		},
+		{
+			name:     "configured host with prefix",
+			rawURL:   "https://TFS.corp.example/tfs/DefaultCollection/project/_git/repo/pullrequest/7",
+			want:     Server{BaseURL: "https://TFS.corp.example/tfs", Organization: "DefaultCollection"},
+			wantRest: []string{"project", "_git", "repo", "pullrequest", "7"},
+		},
+		{
+			name:     "configured host with port and no prefix",
+			rawURL:   "http://ado.corp.example:8080/Collection/project",
+			want:     Server{BaseURL: "http://ado.corp.example:8080", Organization: "Collection"},
+			wantRest: []string{"project"},
+		},
+		{name: "configured host with wrong prefix", rawURL: "https://tfs.corp.example/other/Collection/project", wantErr: true},
+		{name: "configured host without collection", rawURL: "https://tfs.corp.example/tfs", wantErr: true},
+		{name: "unknown host", rawURL: "https://example.com/org/project", wantErr: true},
+		{name: "port must match", rawURL: "https://ado.corp.example/Collection/project", wantErr: true},
+		{name: "not a url", rawURL: "org/project", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, rest, err := SplitURL(tt.rawURL, hosts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SplitURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Fatalf("SplitURL() server = %+v, want %+v", got, tt.want)
+			}
+			if !slices.Equal(rest, tt.wantRest) {
+				t.Fatalf("SplitURL() rest = %q, want %q", rest, tt.wantRest)
+			}
+		})
+	}
+}
@@ -2,17 +2,30 @@ package auth
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"os/exec"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// refreshMargin is how long before expiry a token is treated as expired, so requests
+// never go out with a token that lapses in flight.
+const refreshMargin = 5 * time.Minute
+
 // Auth represents authentication credentials for Azure DevOps.
 type Auth struct {
 	Scheme string // "Bearer" or "Basic"
 	Token  string
+	Source string // Provider that supplied the credentials, e.g. "env" or "azcli"
+
+	// ExpiresOn is when the token expires (zero if unknown or it does not expire, e.g. PATs).
+	ExpiresOn time.Time
+	// Renew fetches fresh credentials, bypassing any cache. Nil when the credentials
+	// cannot be renewed (PATs).
+	Renew func() (*Auth, error)
 }
 
 // AuthorizationHeader returns the formatted Authorization header value.
@@ -24,25 +37,18 @@ func (a *Auth) AuthorizationHeader() string {
 	return "Bearer " + a.Token
 }
 
-// GetAzureAuth retrieves Azure DevOps authentication.
-// It first checks for PAT tokens in environment variables (AZDO_PAT, ADO_PAT),
-// then falls back to Azure CLI if available.
-func GetAzureAuth() (*Auth, error) {
-	// Check for PAT in environment
-	if pat := os.Getenv("AZDO_PAT"); pat != "" {
-		return &Auth{Scheme: "Basic", Token: pat}, nil
-	}
-	if pat := os.Getenv("ADO_PAT"); pat != "" {
-		return &Auth{Scheme: "Basic", Token: pat}, nil
-	}
-
-	// Try Azure CLI
-	auth, err := getAzCLIToken()
-	if err == nil {
-		return auth, nil
-	}
+// ExpiresSoon reports whether the token expires within the refresh margin of now.
+func (a *Auth) ExpiresSoon(now time.Time) bool {
+	return !a.ExpiresOn.IsZero() && now.Add(refreshMargin).After(a.ExpiresOn)
+}
 
-	return nil, errors.New("missing auth: set AZDO_PAT or ADO_PAT, or sign in with `az login`")
+// azTokenOutput is the JSON printed by `az account get-access-token`.
+type azTokenOutput struct {
+	AccessToken string `json:"accessToken"`
+	// ExpiresOn is a local time such as "2025-01-01 12:00:00.000000".
+	ExpiresOn string `json:"expiresOn"`
+	// ExpiresOnUnix is a Unix timestamp, present in newer Azure CLI versions.
+	ExpiresOnUnix json.RawMessage `json:"expires_on"`
 }
 
 // getAzCLIToken retrieves an access token from Azure CLI.
@@ -52,8 +58,7 @@ func getAzCLIToken() (*Auth, error) {
 
 	cmd := exec.Command("az", "account", "get-access-token",
 		"--resource", azureDevOpsResource,
-		"--query", "accessToken",
-		"-o", "tsv",
+		"-o", "json",
 	)
 
 	output, err := cmd.Output()
@@ -61,10 +66,27 @@ func getAzCLIToken() (*Auth, error) {
 		return nil, fmt.Errorf("az cli failed: %w", err)
 	}
 
-	token := strings.TrimSpace(string(output))
+	return parseAzTokenOutput(output)
+}
+
+// parseAzTokenOutput parses the token and its expiry from `az account get-access-token` output.
+// An unparseable expiry is left zero, which disables caching for the token.
+func parseAzTokenOutput(output []byte) (*Auth, error) {
+	var out azTokenOutput
+	if err := json.Unmarshal(output, &out); err != nil {
+		return nil, fmt.Errorf("parse az cli output: %w", err)
+	}
+
+	token := strings.TrimSpace(out.AccessToken)
 	if token == "" {
 		return nil, errors.New("az cli returned empty token")
 	}
 
-	return &Auth{Scheme: "Bearer", Token: token}, nil
+	auth := &Auth{Scheme: "Bearer", Token: token}
+	if secs, err := strconv.ParseInt(strings.Trim(string(out.ExpiresOnUnix), `"`), 10, 64); err == nil && secs > 0 {
+		auth.ExpiresOn = time.Unix(secs, 0)
+	} else if t, err := time.ParseInLocation("2006-01-02 15:04:05.999999", out.ExpiresOn, time.Local); err == nil {
+		auth.ExpiresOn = t
+	}
+	return auth, nil
 }
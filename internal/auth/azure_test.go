@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAzTokenOutput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		output  string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "unix expiry",
+			output: `{"accessToken":"tok","expiresOn":"2025-01-01 12:00:00.000000","expires_on":1735732800}`,
+			want:   time.Unix(1735732800, 0),
+		},
+		{
+			name:   "local time expiry",
+			output: `{"accessToken":"tok","expiresOn":"2025-01-01 12:00:00.000000"}`,
+			want:   time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local),
+		},
+		{
+			name:   "unknown expiry",
+			output: `{"accessToken":"tok","expiresOn":"soon"}`,
+		},
+		{name: "empty token", output: `{"accessToken":""}`, wantErr: true},
+		{name: "not json", output: `tok`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseAzTokenOutput([]byte(tt.output))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAzTokenOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Scheme != "Bearer" || got.Token != "tok" {
+				t.Fatalf("got %+v", got)
+			}
+			if !got.ExpiresOn.Equal(tt.want) {
+				t.Fatalf("ExpiresOn = %v, want %v", got.ExpiresOn, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthExpiresSoon(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	if (&Auth{}).ExpiresSoon(now) {
+		t.Fatal("token without expiry should not expire")
+	}
+	if !(&Auth{ExpiresOn: now.Add(time.Minute)}).ExpiresSoon(now) {
+		t.Fatal("token expiring within the margin should expire soon")
+	}
+	if (&Auth{ExpiresOn: now.Add(time.Hour)}).ExpiresSoon(now) {
+		t.Fatal("token valid for an hour should not expire soon")
+	}
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/krubenok/toolbox/internal/config"
+)
+
+// tokenCacheFile is the file under ~/.toolbox/cache that persists Azure CLI tokens.
+const tokenCacheFile = "az-token.json"
+
+// cachedToken is the on-disk form of a cached Azure CLI token.
+type cachedToken struct {
+	AccessToken string    `json:"accessToken"`
+	ExpiresOn   time.Time `json:"expiresOn"`
+}
+
+// azTokenCache holds the Azure CLI token shared by every client in the process.
+var azTokenCache struct {
+	mu   sync.Mutex
+	auth *Auth
+}
+
+// getAzCLITokenCached returns the cached Azure CLI token, or fetches a new one when there
+// is none, it expires within the refresh margin, or force is set. Tokens without a known
+// expiry are not cached.
+func getAzCLITokenCached(force bool) (*Auth, error) {
+	azTokenCache.mu.Lock()
+	defer azTokenCache.mu.Unlock()
+
+	now := time.Now()
+	if !force {
+		if a := azTokenCache.auth; a != nil && !a.ExpiresSoon(now) {
+			return a, nil
+		}
+	}
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		cfg = &Config{}
+	}
+
+	if !force && cfg.TokenCacheFile {
+		if a := readTokenFile(); a != nil && !a.ExpiresSoon(now) {
+			azTokenCache.auth = a
+			return a, nil
+		}
+	}
+
+	a, err := getAzCLIToken()
+	if err != nil {
+		return nil, err
+	}
+	a.Renew = renewAzCLIToken
+
+	if a.ExpiresOn.IsZero() {
+		azTokenCache.auth = nil
+		return a, nil
+	}
+	azTokenCache.auth = a
+	if cfg.TokenCacheFile {
+		writeTokenFile(a)
+	}
+	return a, nil
+}
+
+// renewAzCLIToken fetches a new Azure CLI token, replacing the cached one.
+func renewAzCLIToken() (*Auth, error) {
+	return getAzCLITokenCached(true)
+}
+
+func tokenFilePath() (string, error) {
+	dir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache", tokenCacheFile), nil
+}
+
+// readTokenFile returns the token persisted in the cache file, or nil if there is none.
+func readTokenFile() *Auth {
+	path, err := tokenFilePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var ct cachedToken
+	if err := json.Unmarshal(data, &ct); err != nil || ct.AccessToken == "" || ct.ExpiresOn.IsZero() {
+		return nil
+	}
+	return &Auth{Scheme: "Bearer", Token: ct.AccessToken, ExpiresOn: ct.ExpiresOn, Renew: renewAzCLIToken}
+}
+
+// writeTokenFile persists the token to the cache file, readable only by the current user.
+// Failures are ignored: the file is only an optimization.
+func writeTokenFile(a *Auth) {
+	path, err := tokenFilePath()
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(cachedToken{AccessToken: a.Token, ExpiresOn: a.ExpiresOn})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	// Write to a temp file and rename so concurrent invocations never read a partial file.
+	tmp, err := os.CreateTemp(filepath.Dir(path), tokenCacheFile+".*")
+	if err != nil {
+		return
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), path)
+}
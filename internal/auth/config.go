@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"os"
+	"strings"
+
+	"github.com/krubenok/toolbox/internal/config"
+)
+
+const configFile = "auth.json"
+
+// Config holds authentication settings from ~/.toolbox/auth.json.
+type Config struct {
+	// TokenCacheFile persists Azure CLI tokens to ~/.toolbox/cache/az-token.json (mode 0600)
+	// so separate toolbox invocations reuse them until they expire. Tokens are always
+	// cached in memory for the lifetime of the process.
+	TokenCacheFile bool `json:"tokenCacheFile,omitempty"`
+
+	// Providers is the ordered credential chain; the first provider with credentials wins.
+	// Empty uses DefaultProviders.
+	Providers []ProviderConfig `json:"providers,omitempty"`
+
+	// Organizations maps an organization (or collection) name to its own provider chain,
+	// replacing Providers for that organization. Names are matched case-insensitively.
+	Organizations map[string]OrgConfig `json:"organizations,omitempty"`
+}
+
+// OrgConfig holds the credential settings for a single organization.
+type OrgConfig struct {
+	Providers []ProviderConfig `json:"providers"`
+}
+
+// ProviderConfig configures one credential provider in the chain.
+type ProviderConfig struct {
+	// Type is one of "env", "azcli", "keyring", "file" or "credentialHelper".
+	Type string `json:"type"`
+	// Vars lists the environment variables holding a PAT (env). Defaults to AZDO_PAT, ADO_PAT.
+	Vars []string `json:"vars,omitempty"`
+	// Path is the file containing a PAT (file). A leading ~/ is expanded to the home directory.
+	Path string `json:"path,omitempty"`
+	// Command is the credential helper command line (credentialHelper), run through the shell.
+	Command string `json:"command,omitempty"`
+}
+
+// DefaultProviders is the chain used when none is configured: PATs from the environment,
+// then a PAT stored with `toolbox auth login`, then the Azure CLI.
+func DefaultProviders() []ProviderConfig {
+	return []ProviderConfig{
+		{Type: providerEnv},
+		{Type: providerKeyring},
+		{Type: providerAzCLI},
+	}
+}
+
+// LoadConfig loads the auth config from ~/.toolbox/auth.json, layered over the
+// "auth" section of the profile for org (see config.LoadForOrg).
+// A repository's .toolbox/auth.json is ignored: providers can run commands and read
+// files, which a checked-out repository must not be able to configure.
+// Falls back to defaults if neither exists.
+func LoadConfig(org string) (*Config, error) {
+	var cfg Config
+	if err := config.LoadUserForOrg(org, configFile, &cfg); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// ProvidersFor returns the provider chain configured for an organization.
+func (c *Config) ProvidersFor(org string) []ProviderConfig {
+	for name, oc := range c.Organizations {
+		if strings.EqualFold(name, org) && len(oc.Providers) > 0 {
+			return oc.Providers
+		}
+	}
+	if len(c.Providers) > 0 {
+		return c.Providers
+	}
+	return DefaultProviders()
+}
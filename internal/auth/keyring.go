@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	// keyringService is the service name PATs are stored under in the OS keyring.
+	keyringService = "toolbox-ado"
+	// keyringDefaultAccount holds the PAT used for organizations without their own entry.
+	keyringDefaultAccount = "default"
+)
+
+// keyringProvider reads a PAT stored with `toolbox auth login` from the OS keyring:
+// the Secret Service (via secret-tool) on Linux or the login keychain on macOS.
+// An entry for the organization wins over the default entry.
+type keyringProvider struct{}
+
+func (keyringProvider) Name() string { return providerKeyring }
+
+func (keyringProvider) Auth(target Target) (*Auth, error) {
+	accounts := []string{keyringAccount(target.Organization)}
+	if target.Organization != "" {
+		accounts = append(accounts, keyringDefaultAccount)
+	}
+	for _, account := range accounts {
+		pat, err := keyringGet(account)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrNoCredentials, err)
+		}
+		if pat != "" {
+			return &Auth{Scheme: "Basic", Token: pat}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no PAT stored (run `toolbox auth login`)", ErrNoCredentials)
+}
+
+// keyringAccount returns the keyring account for an organization (empty = default).
+func keyringAccount(org string) string {
+	if org == "" {
+		return keyringDefaultAccount
+	}
+	return strings.ToLower(org)
+}
+
+// StorePAT saves a PAT in the OS keyring for an organization (empty = all organizations
+// without their own entry), replacing any existing one.
+func StorePAT(org, pat string) error {
+	account := keyringAccount(org)
+	switch runtime.GOOS {
+	case "linux":
+		label := "toolbox Azure DevOps PAT (" + account + ")"
+		cmd := exec.Command("secret-tool", "store", "--label", label, "service", keyringService, "account", account)
+		cmd.Stdin = strings.NewReader(pat)
+		return runKeyring(cmd)
+	case "darwin":
+		// The command is read from stdin (-i) so the PAT never appears in the process
+		// list. -U updates an existing item instead of failing.
+		if strings.ContainsAny(account+pat, "\"\\\r\n") {
+			return errors.New("organization or PAT contains characters that cannot be stored in the keychain")
+		}
+		var stderr bytes.Buffer
+		cmd := exec.Command("security", "-i")
+		cmd.Stdin = strings.NewReader(securityAddCommand(account, pat))
+		cmd.Stderr = &stderr
+		// In interactive mode a failed command is only reported on stderr.
+		err := cmd.Run()
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("security: %s", msg)
+		}
+		if err != nil {
+			return fmt.Errorf("security: %w", err)
+		}
+		return nil
+	}
+	return errUnsupportedKeyring()
+}
+
+// securityAddCommand returns the `security -i` command that stores pat for account.
+func securityAddCommand(account, pat string) string {
+	return fmt.Sprintf("add-generic-password -U -s %s -a \"%s\" -w \"%s\"\n", keyringService, account, pat)
+}
+
+// DeletePAT removes the PAT stored for an organization (empty = the default entry).
+func DeletePAT(org string) error {
+	account := keyringAccount(org)
+	switch runtime.GOOS {
+	case "linux":
+		return runKeyring(exec.Command("secret-tool", "clear", "service", keyringService, "account", account))
+	case "darwin":
+		return runKeyring(exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", account))
+	}
+	return errUnsupportedKeyring()
+}
+
+// keyringGet returns the PAT stored for an account, or "" if there is none.
+func keyringGet(account string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", keyringService, "account", account)
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", keyringService, "-a", account, "-w")
+	default:
+		return "", errUnsupportedKeyring()
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			// Both tools exit non-zero when no matching item exists.
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+func runKeyring(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %s", cmd.Args[0], msg)
+		}
+		return fmt.Errorf("%s: %w", cmd.Args[0], err)
+	}
+	return nil
+}
+
+func errUnsupportedKeyring() error {
+	return fmt.Errorf("OS keyring is not supported on %s; use a file or credentialHelper provider", runtime.GOOS)
+}
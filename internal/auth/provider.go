@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Provider types accepted in ProviderConfig.Type.
+const (
+	providerEnv              = "env"
+	providerAzCLI            = "azcli"
+	providerKeyring          = "keyring"
+	providerFile             = "file"
+	providerCredentialHelper = "credentialHelper"
+)
+
+// servicesBaseURL is the Azure DevOps Services base URL, the only server the Azure CLI
+// provider sends Entra tokens to.
+const servicesBaseURL = "https://dev.azure.com"
+
+// ErrNoCredentials is returned by a Provider that has no credentials for the target,
+// so the chain moves on to the next provider.
+var ErrNoCredentials = errors.New("no credentials")
+
+// Target identifies what credentials are needed for.
+type Target struct {
+	BaseURL      string // API base URL, e.g. https://dev.azure.com
+	Organization string // Organization, or collection on Azure DevOps Server
+}
+
+// Provider supplies credentials for an Azure DevOps organization.
+type Provider interface {
+	// Name identifies the provider in errors and debug output.
+	Name() string
+	// Auth returns credentials for the target, or an error wrapping ErrNoCredentials
+	// when the provider has none.
+	Auth(target Target) (*Auth, error)
+}
+
+// NewProvider creates the provider described by cfg.
+func NewProvider(cfg ProviderConfig) (Provider, error) {
+	switch cfg.Type {
+	case providerEnv:
+		vars := cfg.Vars
+		if len(vars) == 0 {
+			vars = []string{"AZDO_PAT", "ADO_PAT"}
+		}
+		return envProvider{vars: vars}, nil
+	case providerAzCLI:
+		return azCLIProvider{}, nil
+	case providerKeyring:
+		return keyringProvider{}, nil
+	case providerFile:
+		if cfg.Path == "" {
+			return nil, errors.New("file provider requires a path")
+		}
+		return fileProvider{path: cfg.Path}, nil
+	case providerCredentialHelper:
+		if cfg.Command == "" {
+			return nil, errors.New("credentialHelper provider requires a command")
+		}
+		return helperProvider{command: cfg.Command}, nil
+	}
+	return nil, fmt.Errorf("unknown auth provider type %q", cfg.Type)
+}
+
+// GetAzureAuth retrieves Azure DevOps authentication for the target by trying the provider
+// chain from ~/.toolbox/auth.json in order (see DefaultProviders when none is configured).
+// The returned Auth records which provider supplied it in Source.
+func GetAzureAuth(target Target) (*Auth, error) {
+	cfg, err := LoadConfig(target.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load auth config: %w", err)
+	}
+
+	configured := cfg.ProvidersFor(target.Organization)
+	providers := make([]Provider, 0, len(configured))
+	for _, pc := range configured {
+		p, err := NewProvider(pc)
+		if err != nil {
+			return nil, fmt.Errorf("auth config: %w", err)
+		}
+		providers = append(providers, p)
+	}
+
+	a, err := resolve(providers, target)
+	if err != nil && len(cfg.Providers) == 0 && len(cfg.Organizations) == 0 {
+		return nil, fmt.Errorf("%w (set AZDO_PAT or ADO_PAT, run `toolbox auth login`, or sign in with `az login`)", err)
+	}
+	return a, err
+}
+
+// resolve returns the credentials from the first provider in the chain that has them.
+// A provider failure other than ErrNoCredentials stops the chain, so a misconfigured
+// provider is reported rather than silently skipped.
+func resolve(providers []Provider, target Target) (*Auth, error) {
+	var tried []string
+	for _, p := range providers {
+		a, err := p.Auth(target)
+		if err == nil {
+			if a.Source == "" {
+				a.Source = p.Name()
+			}
+			return a, nil
+		}
+		if !errors.Is(err, ErrNoCredentials) {
+			return nil, fmt.Errorf("auth provider %s: %w", p.Name(), err)
+		}
+		reason := strings.TrimPrefix(err.Error(), ErrNoCredentials.Error()+": ")
+		tried = append(tried, fmt.Sprintf("%s (%s)", p.Name(), reason))
+	}
+
+	org := target.Organization
+	if org == "" {
+		org = "this organization"
+	}
+	return nil, fmt.Errorf("missing auth for %s: tried %s", org, strings.Join(tried, "; "))
+}
+
+// envProvider reads a PAT from the first set environment variable.
+type envProvider struct {
+	vars []string
+}
+
+func (p envProvider) Name() string { return providerEnv }
+
+func (p envProvider) Auth(Target) (*Auth, error) {
+	for _, v := range p.vars {
+		if pat := os.Getenv(v); pat != "" {
+			return &Auth{Scheme: "Basic", Token: pat}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s not set", ErrNoCredentials, strings.Join(p.vars, ", "))
+}
+
+// azCLIProvider gets a Bearer token from the Azure CLI. Tokens are cached until shortly
+// before they expire (see getAzCLITokenCached). The token is for the user's Entra identity,
+// so it is only handed out for Azure DevOps Services; Azure DevOps Server hosts should use
+// a PAT or a credential helper.
+type azCLIProvider struct{}
+
+func (azCLIProvider) Name() string { return providerAzCLI }
+
+func (azCLIProvider) Auth(target Target) (*Auth, error) {
+	if base := strings.TrimRight(target.BaseURL, "/"); base != "" && !strings.EqualFold(base, servicesBaseURL) {
+		return nil, fmt.Errorf("%w: only used for %s", ErrNoCredentials, servicesBaseURL)
+	}
+	a, err := getAzCLITokenCached(false)
+	if err != nil {
+		// Not installed or not logged in: let the next provider try.
+		return nil, fmt.Errorf("%w: %v", ErrNoCredentials, err)
+	}
+	return a, nil
+}
+
+// fileProvider reads a PAT from a file.
+type fileProvider struct {
+	path string
+}
+
+func (p fileProvider) Name() string { return providerFile }
+
+func (p fileProvider) Auth(Target) (*Auth, error) {
+	path, err := expandHome(p.path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s does not exist", ErrNoCredentials, path)
+		}
+		return nil, err
+	}
+	pat := strings.TrimSpace(string(data))
+	if pat == "" {
+		return nil, fmt.Errorf("%w: %s is empty", ErrNoCredentials, path)
+	}
+	return &Auth{Scheme: "Basic", Token: pat}, nil
+}
+
+// expandHome expands a leading ~/ to the user's home directory.
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home dir: %w", err)
+	}
+	return filepath.Join(home, rest), nil
+}
+
+// helperProvider runs a credential helper using git's credential helper protocol:
+// the command is run with a "get" argument and key=value lines describing the target on
+// stdin (protocol, host, path), and prints key=value lines back. "password" is used as a
+// PAT; helpers that return "authtype=Bearer" with "credential" supply a Bearer token.
+type helperProvider struct {
+	command string
+}
+
+func (p helperProvider) Name() string { return providerCredentialHelper }
+
+func (p helperProvider) Auth(target Target) (*Auth, error) {
+	input := helperInput(target)
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", p.command+" get")
+	} else {
+		cmd = exec.Command("sh", "-c", p.command+" get")
+	}
+	cmd.Stdin = strings.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// As with git, a failing helper means it has nothing to offer.
+	output, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s: %s", ErrNoCredentials, p.command, msg)
+		}
+		return nil, fmt.Errorf("%w: %s: %v", ErrNoCredentials, p.command, err)
+	}
+	return parseHelperOutput(output)
+}
+
+// helperInput describes the target in git credential helper format.
+func helperInput(target Target) string {
+	protocol, host := "https", "dev.azure.com"
+	if u, err := url.Parse(target.BaseURL); err == nil && u.Host != "" {
+		protocol, host = u.Scheme, u.Host
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "protocol=%s\nhost=%s\n", protocol, host)
+	if target.Organization != "" {
+		fmt.Fprintf(&b, "path=%s\n", target.Organization)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// parseHelperOutput reads the credentials from a credential helper's key=value output.
+func parseHelperOutput(output []byte) (*Auth, error) {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(values["authtype"], "Bearer") && values["credential"] != "" {
+		return &Auth{Scheme: "Bearer", Token: values["credential"]}, nil
+	}
+	if values["password"] != "" {
+		return &Auth{Scheme: "Basic", Token: values["password"]}, nil
+	}
+	return nil, fmt.Errorf("%w: helper returned no password", ErrNoCredentials)
+}
@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/config"
+)
+
+type fakeProvider struct {
+	name string
+	auth *Auth
+	err  error
+}
+
+func (p fakeProvider) Name() string { return p.name }
+
+func (p fakeProvider) Auth(Target) (*Auth, error) { return p.auth, p.err }
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	none := func(name string) Provider {
+		return fakeProvider{name: name, err: fmt.Errorf("%w: nothing here", ErrNoCredentials)}
+	}
+
+	t.Run("first provider with credentials wins", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := resolve([]Provider{
+			none("env"),
+			fakeProvider{name: "file", auth: &Auth{Scheme: "Basic", Token: "pat"}},
+			fakeProvider{name: "azcli", auth: &Auth{Scheme: "Bearer", Token: "tok"}},
+		}, Target{Organization: "org"})
+		if err != nil {
+			t.Fatalf("resolve: %v", err)
+		}
+		if got.Token != "pat" || got.Source != "file" {
+			t.Fatalf("got %+v", got)
+		}
+	})
+
+	t.Run("provider failure stops the chain", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolve([]Provider{
+			fakeProvider{name: "file", err: errors.New("permission denied")},
+			fakeProvider{name: "azcli", auth: &Auth{Scheme: "Bearer", Token: "tok"}},
+		}, Target{})
+		if err == nil || !strings.Contains(err.Error(), "file: permission denied") {
+			t.Fatalf("err = %v", err)
+		}
+	})
+
+	t.Run("no credentials lists what was tried", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := resolve([]Provider{none("env"), none("keyring")}, Target{Organization: "org"})
+		want := "missing auth for org: tried env (nothing here); keyring (nothing here)"
+		if err == nil || err.Error() != want {
+			t.Fatalf("err = %v, want %q", err, want)
+		}
+	})
+}
+
+func TestConfigProvidersFor(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Providers: []ProviderConfig{{Type: "azcli"}},
+		Organizations: map[string]OrgConfig{
+			"Contoso": {Providers: []ProviderConfig{{Type: "file", Path: "~/.contoso-pat"}}},
+		},
+	}
+
+	if got := cfg.ProvidersFor("contoso"); len(got) != 1 || got[0].Type != "file" {
+		t.Fatalf("contoso providers = %+v", got)
+	}
+	if got := cfg.ProvidersFor("fabrikam"); len(got) != 1 || got[0].Type != "azcli" {
+		t.Fatalf("fabrikam providers = %+v", got)
+	}
+	if got := (&Config{}).ProvidersFor("org"); len(got) != len(DefaultProviders()) {
+		t.Fatalf("default providers = %+v", got)
+	}
+}
+
+func TestNewProviderValidatesConfig(t *testing.T) {
+	t.Parallel()
+
+	for _, cfg := range []ProviderConfig{{Type: "file"}, {Type: "credentialHelper"}, {Type: "vault"}} {
+		if _, err := NewProvider(cfg); err == nil {
+			t.Fatalf("NewProvider(%+v) succeeded, want error", cfg)
+		}
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "pat")
+	if err := os.WriteFile(path, []byte("secret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := fileProvider{path: path}.Auth(Target{})
+	if err != nil {
+		t.Fatalf("Auth: %v", err)
+	}
+	if got.Scheme != "Basic" || got.Token != "secret" {
+		t.Fatalf("got %+v", got)
+	}
+
+	_, err = fileProvider{path: filepath.Join(dir, "missing")}.Auth(Target{})
+	if !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("missing file err = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestAzCLIProviderSkipsOtherServers(t *testing.T) {
+	t.Parallel()
+
+	for _, baseURL := range []string{"https://tfs.corp.example/tfs", "http://dev.azure.com.evil.example"} {
+		_, err := azCLIProvider{}.Auth(Target{BaseURL: baseURL, Organization: "DefaultCollection"})
+		if !errors.Is(err, ErrNoCredentials) || !strings.Contains(err.Error(), "only used for") {
+			t.Fatalf("Auth(%s) err = %v, want ErrNoCredentials before calling az", baseURL, err)
+		}
+	}
+}
+
+func TestCredentialHelperProtocol(t *testing.T) {
+	t.Parallel()
+
+	input := helperInput(Target{BaseURL: "https://tfs.corp.example/tfs", Organization: "DefaultCollection"})
+	if want := "protocol=https\nhost=tfs.corp.example\npath=DefaultCollection\n\n"; input != want {
+		t.Fatalf("helperInput = %q, want %q", input, want)
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		want   Auth
+	}{
+		{name: "password", output: "username=me\npassword=pat\n", want: Auth{Scheme: "Basic", Token: "pat"}},
+		{name: "bearer", output: "authtype=Bearer\ncredential=tok\n", want: Auth{Scheme: "Bearer", Token: "tok"}},
+	}
+	for _, tt := range tests {
+		got, err := parseHelperOutput([]byte(tt.output))
+		if err != nil {
+			t.Fatalf("%s: parseHelperOutput: %v", tt.name, err)
+		}
+		if got.Scheme != tt.want.Scheme || got.Token != tt.want.Token {
+			t.Fatalf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := parseHelperOutput([]byte("quit=1\n")); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("empty helper output err = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestGetAzureAuthReportsProviders(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("AZDO_PAT", "")
+	t.Setenv("ADO_PAT", "")
+	t.Setenv("PATH", "")
+	t.Setenv(config.ProfileEnv, "")
+	t.Chdir(home)
+
+	_, err := GetAzureAuth(Target{BaseURL: "https://tfs.corp.example/tfs", Organization: "DefaultCollection"})
+	if err == nil {
+		t.Fatal("GetAzureAuth succeeded without credentials")
+	}
+	for _, want := range []string{"tried env", "keyring (", "azcli (only used for", "toolbox auth login"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err = %v, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestSecurityAddCommand(t *testing.T) {
+	t.Parallel()
+
+	got := securityAddCommand("contoso", "abc123")
+	want := "add-generic-password -U -s toolbox-ado -a \"contoso\" -w \"abc123\"\n"
+	if got != want {
+		t.Fatalf("securityAddCommand = %q, want %q", got, want)
+	}
+}
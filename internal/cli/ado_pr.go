@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+)
+
+var adoPRCmd = &cobra.Command{
+	Use:   "ado-pr <PR_URL>",
+	Short: "Fetch pull request details from Azure DevOps",
+	Long: `Fetch and display pull request metadata from Azure DevOps: title, description,
+author, source/target branches, merge status, draft flag, reviewers with their
+votes, and linked work item IDs.
+
+` + authHelp("Code -> Read") + `
+Output:
+  By default, output is in TOON format (token-optimized notation).
+  Use --json for standard JSON output.
+
+  Field inclusion is configured under "prOutput" in ~/.toolbox/ado-pr-comments.json.
+
+Examples:
+  toolbox ado-pr https://dev.azure.com/org/project/_git/repo/pullrequest/123
+  toolbox ado-pr <PR_URL> --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdoPR,
+}
+
+var (
+	adoPRDetailsOutputJSON bool
+	adoPRDetailsDebug      bool
+)
+
+func init() {
+	rootCmd.AddCommand(adoPRCmd)
+
+	adoPRCmd.Flags().BoolVar(&adoPRDetailsOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoPRCmd.Flags().BoolVar(&adoPRDetailsDebug, "debug", false, "Print debug info to stderr")
+
+	addShowConfig(adoPRCmd, prCommentsConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoPR(cmd *cobra.Command, args []string) error {
+	opts := adoprcomments.PROptions{
+		Ctx:        cmd.Context(),
+		PRURL:      args[0],
+		OutputJSON: adoPRDetailsOutputJSON,
+		Debug:      adoPRDetailsDebug,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoprcomments.GetPR(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
@@ -10,14 +10,15 @@ import (
 )
 
 var adoPRCommentsCmd = &cobra.Command{
-	Use:   "ado-pr-comments <PR_URL>",
+	Use:   "ado-pr-comments [PR_URL]",
 	Short: "Fetch pull request comments from Azure DevOps",
 	Long: `Fetch and display pull request comments from Azure DevOps.
 
-Auth:
-  - Uses Azure CLI login if available (Bearer token for Azure DevOps).
-  - Otherwise set AZDO_PAT or ADO_PAT (Code -> Read) for Basic auth.
+If no PR URL is given (or --current is set), the PR is inferred from the git
+checkout in the working directory: the origin remote identifies the repository
+and the active PR whose source is the current branch is used.
 
+` + authHelp("Code -> Read") + `
 Output:
   By default, output is in TOON format (token-optimized notation).
   Use --json for standard JSON output.
@@ -56,12 +57,19 @@ Status Filtering:
 
   Valid statuses: active, fixed, closed, byDesign, pending, wontFix
 
+Code Context:
+  Use --context N to attach the commented lines plus N lines above and below
+  to each file-anchored thread. The file is read at the source commit of the
+  iteration the thread was created on.
+
 Examples:
   toolbox ado-pr-comments https://dev.azure.com/org/project/_git/repo/pullrequest/123
   toolbox ado-pr-comments https://org.visualstudio.com/project/_git/repo/pullrequest/123 --status active
   toolbox ado-pr-comments <PR_URL> --json
-  toolbox ado-pr-comments <PR_URL> --no-filter`,
-	Args: cobra.ExactArgs(1),
+  toolbox ado-pr-comments <PR_URL> --no-filter
+  toolbox ado-pr-comments <PR_URL> --status active --context 3
+  toolbox ado-pr-comments --current --status active`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runAdoPRComments,
 }
 
@@ -70,6 +78,8 @@ var (
 	adoPROutputJSON bool
 	adoPRDebug      bool
 	adoPRNoFilter   bool
+	adoPRContext    int
+	adoPRCurrent    bool
 )
 
 func init() {
@@ -79,12 +89,27 @@ func init() {
 	adoPRCommentsCmd.Flags().BoolVar(&adoPROutputJSON, "json", false, "Output JSON instead of TOON format")
 	adoPRCommentsCmd.Flags().BoolVar(&adoPRDebug, "debug", false, "Print debug info to stderr")
 	adoPRCommentsCmd.Flags().BoolVar(&adoPRNoFilter, "no-filter", false, "Disable content filtering")
+	adoPRCommentsCmd.Flags().BoolVar(&adoPRCurrent, "current", false, "Use the active PR for the current git branch (default when no PR URL is given)")
+	adoPRCommentsCmd.Flags().IntVar(&adoPRContext, "context", 0, "Attach the commented code plus N surrounding lines to file-anchored threads")
+
+	// --status replaces status.include from the config files.
+	prCommentsConfig := prCommentsConfigSource
+	prCommentsConfig.flags = map[string]string{"status": "status.include"}
+	addShowConfig(adoPRCommentsCmd, prCommentsConfig, adoConfigSource, authConfigSource)
 }
 
 func runAdoPRComments(cmd *cobra.Command, args []string) error {
+	var prURL string
+	if len(args) == 1 {
+		if adoPRCurrent {
+			return fmt.Errorf("--current cannot be used with a PR URL")
+		}
+		prURL = args[0]
+	}
+
 	opts := adoprcomments.Options{
 		Ctx:        cmd.Context(),
-		PRURL:      args[0],
+		PRURL:      prURL,
 		Statuses:   adoPRStatuses,
 		OutputJSON: adoPROutputJSON,
 		Debug:      adoPRDebug,
@@ -92,6 +117,9 @@ func runAdoPRComments(cmd *cobra.Command, args []string) error {
 		DebugLog: func(msg string) {
 			fmt.Fprintln(os.Stderr, msg)
 		},
+
+		IncludeCodeContext: cmd.Flags().Changed("context"),
+		ContextLines:       adoPRContext,
 	}
 
 	result, err := adoprcomments.Run(opts)
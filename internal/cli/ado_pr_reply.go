@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+)
+
+var adoPRReplyCmd = &cobra.Command{
+	Use:   "ado-pr-reply <PR_URL>",
+	Short: "Reply to a pull request comment thread in Azure DevOps",
+	Long: `Post a comment to an existing pull request comment thread in Azure DevOps.
+
+Thread IDs are shown as "id" on each thread in ado-pr-comments output, and comment
+IDs as "id" on each comment.
+
+` + authHelp("Code -> Read & Write") + `
+Output:
+  Prints the created comment. By default, output is in TOON format.
+  Use --json for standard JSON output.
+
+Examples:
+  toolbox ado-pr-reply https://dev.azure.com/org/project/_git/repo/pullrequest/123 --thread 42 --message "Fixed in the latest iteration."
+  toolbox ado-pr-reply <PR_URL> --thread 42 --parent 1 --message "Good catch, done."`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdoPRReply,
+}
+
+var (
+	adoPRReplyThreadID   int
+	adoPRReplyParentID   int
+	adoPRReplyMessage    string
+	adoPRReplyOutputJSON bool
+	adoPRReplyDebug      bool
+)
+
+func init() {
+	rootCmd.AddCommand(adoPRReplyCmd)
+
+	adoPRReplyCmd.Flags().IntVar(&adoPRReplyThreadID, "thread", 0, "ID of the thread to reply to (required)")
+	adoPRReplyCmd.Flags().IntVar(&adoPRReplyParentID, "parent", 0, "ID of the comment being replied to (optional)")
+	adoPRReplyCmd.Flags().StringVar(&adoPRReplyMessage, "message", "", "Comment text, markdown supported (required)")
+	adoPRReplyCmd.Flags().BoolVar(&adoPRReplyOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoPRReplyCmd.Flags().BoolVar(&adoPRReplyDebug, "debug", false, "Print debug info to stderr")
+
+	_ = adoPRReplyCmd.MarkFlagRequired("thread")
+	_ = adoPRReplyCmd.MarkFlagRequired("message")
+
+	addShowConfig(adoPRReplyCmd, prCommentsConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoPRReply(cmd *cobra.Command, args []string) error {
+	opts := adoprcomments.ReplyOptions{
+		Ctx:             cmd.Context(),
+		PRURL:           args[0],
+		ThreadID:        adoPRReplyThreadID,
+		ParentCommentID: adoPRReplyParentID,
+		Message:         adoPRReplyMessage,
+		OutputJSON:      adoPRReplyOutputJSON,
+		Debug:           adoPRReplyDebug,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoprcomments.Reply(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
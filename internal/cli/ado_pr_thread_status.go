@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+)
+
+var adoPRThreadStatusCmd = &cobra.Command{
+	Use:   "ado-pr-thread-status <PR_URL>",
+	Short: "Change the status of a pull request comment thread in Azure DevOps",
+	Long: `Change the status of a pull request comment thread in Azure DevOps
+(for example, resolve it as fixed or won't fix).
+
+Thread IDs are shown as "id" on each thread in ado-pr-comments output.
+
+` + authHelp("Code -> Read & Write") + `
+Output:
+  Prints the updated thread in the same shape as ado-pr-comments.
+  By default, output is in TOON format. Use --json for standard JSON output.
+
+Valid statuses: active, fixed, closed, byDesign, pending, wontFix
+
+Examples:
+  toolbox ado-pr-thread-status https://dev.azure.com/org/project/_git/repo/pullrequest/123 --thread 42 --status fixed
+  toolbox ado-pr-thread-status <PR_URL> --thread 42 --status wontFix --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdoPRThreadStatus,
+}
+
+var (
+	adoPRThreadStatusThreadID   int
+	adoPRThreadStatusStatus     string
+	adoPRThreadStatusOutputJSON bool
+	adoPRThreadStatusDebug      bool
+	adoPRThreadStatusNoFilter   bool
+)
+
+func init() {
+	rootCmd.AddCommand(adoPRThreadStatusCmd)
+
+	adoPRThreadStatusCmd.Flags().IntVar(&adoPRThreadStatusThreadID, "thread", 0, "ID of the thread to update (required)")
+	adoPRThreadStatusCmd.Flags().StringVar(&adoPRThreadStatusStatus, "status", "", "New thread status: active, fixed, closed, byDesign, pending, wontFix (required)")
+	adoPRThreadStatusCmd.Flags().BoolVar(&adoPRThreadStatusOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoPRThreadStatusCmd.Flags().BoolVar(&adoPRThreadStatusDebug, "debug", false, "Print debug info to stderr")
+	adoPRThreadStatusCmd.Flags().BoolVar(&adoPRThreadStatusNoFilter, "no-filter", false, "Disable content filtering")
+
+	_ = adoPRThreadStatusCmd.MarkFlagRequired("thread")
+	_ = adoPRThreadStatusCmd.MarkFlagRequired("status")
+
+	addShowConfig(adoPRThreadStatusCmd, prCommentsConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoPRThreadStatus(cmd *cobra.Command, args []string) error {
+	opts := adoprcomments.SetStatusOptions{
+		Ctx:        cmd.Context(),
+		PRURL:      args[0],
+		ThreadID:   adoPRThreadStatusThreadID,
+		Status:     adoPRThreadStatusStatus,
+		OutputJSON: adoPRThreadStatusOutputJSON,
+		Debug:      adoPRThreadStatusDebug,
+		NoFilter:   adoPRThreadStatusNoFilter,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoprcomments.SetStatus(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+var adoWIQLCmd = &cobra.Command{
+	Use:   "ado-wiql <QUERY>",
+	Short: "Query Azure DevOps work items with WIQL",
+	Long: `Run a WIQL (Work Item Query Language) query against Azure DevOps and print
+the matching work items.
+
+The organization and project come from --project-url, which accepts any
+Azure DevOps URL inside the project (project home, board, or work item URL).
+Team macros such as @CurrentIteration require --team.
+
+` + authHelp("Work Items -> Read") + `
+Output:
+  By default, matching items are printed as a compact TOON table
+  (id, type, state, assignedTo, title). Use --expand to return full work
+  item details (same shape as ado-work-item, without discussion).
+  Use --json for standard JSON output.
+
+Examples:
+  toolbox ado-wiql "SELECT [System.Id] FROM WorkItems WHERE [System.AssignedTo] = @Me AND [System.WorkItemType] = 'Bug' AND [System.State] = 'Active' AND [System.IterationPath] = @CurrentIteration" \
+    --project-url https://dev.azure.com/org/project --team "My Team"
+
+  toolbox ado-wiql "SELECT [System.Id] FROM WorkItemLinks WHERE [Source].[System.Id] = 1234 AND [System.Links.LinkType] = 'System.LinkTypes.Hierarchy-Forward' MODE (Recursive)" \
+    --project-url https://dev.azure.com/org/project --expand`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdoWIQL,
+}
+
+var (
+	adoWIQLProjectURL string
+	adoWIQLTeam       string
+	adoWIQLTop        int
+	adoWIQLExpand     bool
+	adoWIQLOutputJSON bool
+	adoWIQLDebug      bool
+)
+
+func init() {
+	rootCmd.AddCommand(adoWIQLCmd)
+
+	adoWIQLCmd.Flags().StringVar(&adoWIQLProjectURL, "project-url", "", "Azure DevOps URL identifying the organization and project (required)")
+	adoWIQLCmd.Flags().StringVar(&adoWIQLTeam, "team", "", "Team name, required for team macros such as @CurrentIteration")
+	adoWIQLCmd.Flags().IntVar(&adoWIQLTop, "top", 0, "Maximum number of work items to return (0 = 100, max 1000)")
+	adoWIQLCmd.Flags().BoolVar(&adoWIQLExpand, "expand", false, "Return full work item details instead of a compact table")
+	adoWIQLCmd.Flags().BoolVar(&adoWIQLOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoWIQLCmd.Flags().BoolVar(&adoWIQLDebug, "debug", false, "Print debug info to stderr")
+
+	_ = adoWIQLCmd.MarkFlagRequired("project-url")
+
+	addShowConfig(adoWIQLCmd, workItemConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoWIQL(cmd *cobra.Command, args []string) error {
+	opts := adoworkitem.QueryOptions{
+		Ctx:        cmd.Context(),
+		ProjectURL: adoWIQLProjectURL,
+		Team:       adoWIQLTeam,
+		Query:      args[0],
+		Top:        adoWIQLTop,
+		Expand:     adoWIQLExpand,
+
+		OutputJSON: adoWIQLOutputJSON,
+		Debug:      adoWIQLDebug,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoworkitem.Query(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
@@ -14,10 +14,7 @@ var adoWorkItemCmd = &cobra.Command{
 	Short: "Fetch work item details from Azure DevOps",
 	Long: `Fetch and display work item details from Azure DevOps.
 
-Auth:
-  - Uses Azure CLI login if available (Bearer token for Azure DevOps).
-  - Otherwise set AZDO_PAT or ADO_PAT (Work Items -> Read) for Basic auth.
-
+` + authHelp("Work Items -> Read") + `
 Output:
   By default, output is in TOON format (token-optimized notation).
   Use --json for standard JSON output.
@@ -26,13 +23,39 @@ Included By Default:
   - Description
   - Discussion (work item comments)
   - Child links
+  - Other links (parent, related, predecessor/successor, pull requests,
+    commits, builds), with artifact links decoded into web URLs
   - Attachment links
 
+Attachments:
+  Use --download-attachments to download attachments into
+  ~/.toolbox/cache/attachments (files over 10 MiB are skipped). Small text,
+  log and JSON attachments are inlined into the output; every downloaded file
+  is listed with its local path.
+
+Additional Fields:
+  Use --field (repeatable or comma-separated) to add any field by reference name,
+  including custom fields. Fields listed under "fields" in
+  ~/.toolbox/ado-work-item.json are always added. Identity values are shown as
+  display names and HTML values are converted to text.
+
+Child Hierarchy:
+  Use --depth N to resolve child links into a nested tree N levels deep, with
+  each child's title, type, state and assignee. Each level is fetched in one
+  batch request; cycles are skipped and the tree is capped at --max-nodes
+  work items (default 500).
+
+Subcommands:
+  create and update write work items; see "toolbox ado-work-item create --help".
+
 Examples:
   toolbox ado-work-item https://dev.azure.com/org/project/_workitems/edit/1144734
   toolbox ado-work-item https://org.visualstudio.com/project/_workitems/edit/1144734 --json
   toolbox ado-work-item <WORK_ITEM_URL> --no-discussion
-  toolbox ado-work-item <WORK_ITEM_URL> --max-comments 50`,
+  toolbox ado-work-item <WORK_ITEM_URL> --max-comments 50
+  toolbox ado-work-item <WORK_ITEM_URL> --depth 3 --no-discussion
+  toolbox ado-work-item <WORK_ITEM_URL> --field System.AreaPath,Microsoft.VSTS.Common.Priority
+  toolbox ado-work-item <WORK_ITEM_URL> --download-attachments`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAdoWorkItem,
 }
@@ -44,8 +67,13 @@ var (
 	adoWIDNoDescription bool
 	adoWIDNoDiscussion  bool
 	adoWIDNoChildren    bool
+	adoWIDNoLinks       bool
 	adoWIDNoAttachments bool
 	adoWIDMaxComments   int
+	adoWIDDownload      bool
+	adoWIDFields        []string
+	adoWIDDepth         int
+	adoWIDMaxNodes      int
 )
 
 func init() {
@@ -57,8 +85,15 @@ func init() {
 	adoWorkItemCmd.Flags().BoolVar(&adoWIDNoDescription, "no-description", false, "Do not include the work item description")
 	adoWorkItemCmd.Flags().BoolVar(&adoWIDNoDiscussion, "no-discussion", false, "Do not include work item comments/discussion")
 	adoWorkItemCmd.Flags().BoolVar(&adoWIDNoChildren, "no-children", false, "Do not include child work item links")
+	adoWorkItemCmd.Flags().BoolVar(&adoWIDNoLinks, "no-links", false, "Do not include parent, related, dependency and artifact links")
 	adoWorkItemCmd.Flags().BoolVar(&adoWIDNoAttachments, "no-attachments", false, "Do not include attachment links")
+	adoWorkItemCmd.Flags().BoolVar(&adoWIDDownload, "download-attachments", false, "Download attachments into the local cache and inline small text attachments")
 	adoWorkItemCmd.Flags().IntVar(&adoWIDMaxComments, "max-comments", 0, "Maximum number of discussion comments to fetch (0 = no limit)")
+	adoWorkItemCmd.Flags().StringSliceVar(&adoWIDFields, "field", nil, "Additional field reference names to include (comma-separated or repeated, e.g., --field System.Tags)")
+	adoWorkItemCmd.Flags().IntVar(&adoWIDDepth, "depth", 0, "Resolve child links into a nested tree this many levels deep (0 = direct links only, max 10)")
+	adoWorkItemCmd.Flags().IntVar(&adoWIDMaxNodes, "max-nodes", 0, "Maximum number of descendants to fetch with --depth (0 = 500)")
+
+	addShowConfig(adoWorkItemCmd, workItemConfigSource, adoConfigSource, authConfigSource)
 }
 
 func runAdoWorkItem(cmd *cobra.Command, args []string) error {
@@ -66,11 +101,16 @@ func runAdoWorkItem(cmd *cobra.Command, args []string) error {
 		Ctx:         cmd.Context(),
 		WorkItemURL: args[0],
 
-		IncludeDescription: !adoWIDNoDescription,
-		IncludeDiscussion:  !adoWIDNoDiscussion,
-		IncludeChildren:    !adoWIDNoChildren,
-		IncludeAttachments: !adoWIDNoAttachments,
-		MaxComments:        adoWIDMaxComments,
+		IncludeDescription:  !adoWIDNoDescription,
+		IncludeDiscussion:   !adoWIDNoDiscussion,
+		IncludeChildren:     !adoWIDNoChildren,
+		IncludeLinks:        !adoWIDNoLinks,
+		IncludeAttachments:  !adoWIDNoAttachments,
+		MaxComments:         adoWIDMaxComments,
+		DownloadAttachments: adoWIDDownload,
+		Fields:              adoWIDFields,
+		ChildDepth:          adoWIDDepth,
+		MaxChildNodes:       adoWIDMaxNodes,
 
 		OutputJSON: adoWIDOutputJSON,
 		Debug:      adoWIDDebug,
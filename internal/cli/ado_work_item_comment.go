@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+var adoWorkItemCommentCmd = &cobra.Command{
+	Use:   "ado-work-item-comment <WORK_ITEM_URL>",
+	Short: "Post a comment to an Azure DevOps work item discussion",
+	Long: `Post a markdown comment to a work item's discussion in Azure DevOps.
+
+Mention users by unique name (usually their email) as @<unique name> or
+@user@domain.com. Each mention is resolved to an Azure DevOps identity, so
+the mentioned user is notified; the comment is not posted if a mention does
+not match exactly one user.
+
+` + authHelp("Work Items -> Read & Write; Identity -> Read to resolve mentions") + `
+Output:
+  Prints the created comment. By default, output is in TOON format.
+  Use --json for standard JSON output.
+
+Examples:
+  toolbox ado-work-item-comment https://dev.azure.com/org/project/_workitems/edit/1144734 --message "Fixed in PR 42."
+  toolbox ado-work-item-comment <WORK_ITEM_URL> --message "@jane@example.com can you verify on **staging**?"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdoWorkItemComment,
+}
+
+var (
+	adoWICommentMessage    string
+	adoWICommentOutputJSON bool
+	adoWICommentDebug      bool
+)
+
+func init() {
+	rootCmd.AddCommand(adoWorkItemCommentCmd)
+
+	adoWorkItemCommentCmd.Flags().StringVar(&adoWICommentMessage, "message", "", "Comment text in markdown, with @mentions (required)")
+	adoWorkItemCommentCmd.Flags().BoolVar(&adoWICommentOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoWorkItemCommentCmd.Flags().BoolVar(&adoWICommentDebug, "debug", false, "Print debug info to stderr")
+
+	_ = adoWorkItemCommentCmd.MarkFlagRequired("message")
+
+	addShowConfig(adoWorkItemCommentCmd, workItemConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoWorkItemComment(cmd *cobra.Command, args []string) error {
+	opts := adoworkitem.AddCommentOptions{
+		Ctx:         cmd.Context(),
+		WorkItemURL: args[0],
+		Message:     adoWICommentMessage,
+
+		OutputJSON: adoWICommentOutputJSON,
+		Debug:      adoWICommentDebug,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoworkitem.AddComment(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
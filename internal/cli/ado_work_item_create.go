@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+var adoWorkItemCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an Azure DevOps work item",
+	Long: `Create a work item in Azure DevOps.
+
+The organization and project come from --project-url, which accepts any
+Azure DevOps URL inside the project (project home, board, or work item URL).
+The description is written in markdown and converted to HTML.
+
+` + authHelp("Work Items -> Read & Write") + `
+Output:
+  Prints the created work item in the same shape as ado-work-item.
+  With --dry-run, prints the JSON Patch document instead of sending it.
+  Use --json for standard JSON output.
+
+Examples:
+  toolbox ado-work-item create --project-url https://dev.azure.com/org/project \
+    --type Bug --title "Crash when saving" --description "Saving a file with **unicode** names crashes"
+  toolbox ado-work-item create --project-url <PROJECT_URL> --type Task --title "Add tests" \
+    --parent 1144734 --assigned-to jane@example.com --tags testing --dry-run`,
+	Args: cobra.NoArgs,
+	RunE: runAdoWorkItemCreate,
+}
+
+// workItemChangeFlags holds the field flags shared by the create and update subcommands.
+type workItemChangeFlags struct {
+	title         string
+	description   string
+	state         string
+	assignedTo    string
+	areaPath      string
+	iterationPath string
+	tags          []string
+	parent        string
+}
+
+func (f *workItemChangeFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.title, "title", "", "Work item title")
+	cmd.Flags().StringVar(&f.description, "description", "", "Description in markdown (converted to HTML)")
+	cmd.Flags().StringVar(&f.state, "state", "", "State, e.g. Active or Resolved")
+	cmd.Flags().StringVar(&f.assignedTo, "assigned-to", "", "Assignee display name or email")
+	cmd.Flags().StringVar(&f.areaPath, "area", "", `Area path, e.g. Project\Team`)
+	cmd.Flags().StringVar(&f.iterationPath, "iteration", "", `Iteration path, e.g. Project\Sprint 12`)
+	cmd.Flags().StringSliceVar(&f.tags, "tags", nil, "Tags, replacing existing tags (comma-separated or repeated; empty clears tags)")
+	cmd.Flags().StringVar(&f.parent, "parent", "", "Parent work item ID or URL")
+}
+
+func (f *workItemChangeFlags) changes(cmd *cobra.Command) adoworkitem.WorkItemChanges {
+	changes := adoworkitem.WorkItemChanges{
+		Title:         f.title,
+		Description:   f.description,
+		State:         f.state,
+		AssignedTo:    f.assignedTo,
+		AreaPath:      f.areaPath,
+		IterationPath: f.iterationPath,
+		Parent:        f.parent,
+	}
+	if cmd.Flags().Changed("tags") {
+		changes.Tags = append([]string{}, f.tags...)
+	}
+	return changes
+}
+
+var (
+	adoWICreateProjectURL string
+	adoWICreateType       string
+	adoWICreateChanges    workItemChangeFlags
+	adoWICreateDryRun     bool
+	adoWICreateOutputJSON bool
+	adoWICreateDebug      bool
+)
+
+func init() {
+	adoWorkItemCmd.AddCommand(adoWorkItemCreateCmd)
+
+	adoWorkItemCreateCmd.Flags().StringVar(&adoWICreateProjectURL, "project-url", "", "Azure DevOps URL identifying the organization and project (required)")
+	adoWorkItemCreateCmd.Flags().StringVar(&adoWICreateType, "type", "", "Work item type, e.g. Bug, Task, User Story (required)")
+	adoWICreateChanges.register(adoWorkItemCreateCmd)
+	adoWorkItemCreateCmd.Flags().BoolVar(&adoWICreateDryRun, "dry-run", false, "Print the JSON Patch document without creating the work item")
+	adoWorkItemCreateCmd.Flags().BoolVar(&adoWICreateOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoWorkItemCreateCmd.Flags().BoolVar(&adoWICreateDebug, "debug", false, "Print debug info to stderr")
+
+	_ = adoWorkItemCreateCmd.MarkFlagRequired("project-url")
+	_ = adoWorkItemCreateCmd.MarkFlagRequired("type")
+	_ = adoWorkItemCreateCmd.MarkFlagRequired("title")
+
+	addShowConfig(adoWorkItemCreateCmd, workItemConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoWorkItemCreate(cmd *cobra.Command, args []string) error {
+	opts := adoworkitem.CreateOptions{
+		Ctx:        cmd.Context(),
+		ProjectURL: adoWICreateProjectURL,
+		Type:       adoWICreateType,
+		Changes:    adoWICreateChanges.changes(cmd),
+
+		DryRun:     adoWICreateDryRun,
+		OutputJSON: adoWICreateOutputJSON,
+		Debug:      adoWICreateDebug,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoworkitem.Create(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+var adoWorkItemHistoryCmd = &cobra.Command{
+	Use:   "ado-work-item-history <WORK_ITEM_URL>",
+	Short: "Show the change history of an Azure DevOps work item",
+	Long: `Show who changed which work item field, when, and from what to what.
+
+Each revision lists its field changes (state transitions, reassignments,
+description edits, ...) and any links added or removed. Fields that change on
+every revision (ChangedDate, Rev, ...) are left out. Identity fields are shown
+as display names, HTML fields are converted to text, and multi-line text
+changes are shown as a line diff.
+
+` + authHelp("Work Items -> Read") + `
+Output:
+  By default, output is a compact TOON timeline, oldest revision first.
+  Use --json for standard JSON output.
+
+Examples:
+  toolbox ado-work-item-history https://dev.azure.com/org/project/_workitems/edit/1144734
+  toolbox ado-work-item-history <WORK_ITEM_URL> --field System.State --field System.AssignedTo
+  toolbox ado-work-item-history <WORK_ITEM_URL> --max-updates 20 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdoWorkItemHistory,
+}
+
+var (
+	adoWIHFields     []string
+	adoWIHMaxUpdates int
+	adoWIHOutputJSON bool
+	adoWIHDebug      bool
+)
+
+func init() {
+	rootCmd.AddCommand(adoWorkItemHistoryCmd)
+
+	adoWorkItemHistoryCmd.Flags().StringSliceVar(&adoWIHFields, "field", nil, "Only show changes to these field reference names (comma-separated or repeated)")
+	adoWorkItemHistoryCmd.Flags().IntVar(&adoWIHMaxUpdates, "max-updates", 0, "Show only the most recent revisions left after filtering, up to this many (0 = no limit)")
+	adoWorkItemHistoryCmd.Flags().BoolVar(&adoWIHOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoWorkItemHistoryCmd.Flags().BoolVar(&adoWIHDebug, "debug", false, "Print debug info to stderr")
+
+	addShowConfig(adoWorkItemHistoryCmd, workItemConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoWorkItemHistory(cmd *cobra.Command, args []string) error {
+	opts := adoworkitem.HistoryOptions{
+		Ctx:         cmd.Context(),
+		WorkItemURL: args[0],
+		Fields:      adoWIHFields,
+		MaxUpdates:  adoWIHMaxUpdates,
+
+		OutputJSON: adoWIHOutputJSON,
+		Debug:      adoWIHDebug,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoworkitem.History(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
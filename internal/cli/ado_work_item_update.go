@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+var adoWorkItemUpdateCmd = &cobra.Command{
+	Use:   "update <WORK_ITEM_URL>",
+	Short: "Update an Azure DevOps work item",
+	Long: `Update fields of an existing Azure DevOps work item.
+
+Only the fields given as flags are changed. The description is written in
+markdown and converted to HTML; --tags replaces all existing tags; --parent
+adds a parent link.
+
+` + authHelp("Work Items -> Read & Write") + `
+Output:
+  Prints the updated work item in the same shape as ado-work-item.
+  With --dry-run, prints the JSON Patch document instead of sending it.
+  Use --json for standard JSON output.
+
+Examples:
+  toolbox ado-work-item update https://dev.azure.com/org/project/_workitems/edit/1144734 --state Resolved
+  toolbox ado-work-item update <WORK_ITEM_URL> --assigned-to jane@example.com --iteration "Project\Sprint 12"
+  toolbox ado-work-item update <WORK_ITEM_URL> --tags triaged,regression --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAdoWorkItemUpdate,
+}
+
+var (
+	adoWIUpdateChanges    workItemChangeFlags
+	adoWIUpdateDryRun     bool
+	adoWIUpdateOutputJSON bool
+	adoWIUpdateDebug      bool
+)
+
+func init() {
+	adoWorkItemCmd.AddCommand(adoWorkItemUpdateCmd)
+
+	adoWIUpdateChanges.register(adoWorkItemUpdateCmd)
+	adoWorkItemUpdateCmd.Flags().BoolVar(&adoWIUpdateDryRun, "dry-run", false, "Print the JSON Patch document without updating the work item")
+	adoWorkItemUpdateCmd.Flags().BoolVar(&adoWIUpdateOutputJSON, "json", false, "Output JSON instead of TOON format")
+	adoWorkItemUpdateCmd.Flags().BoolVar(&adoWIUpdateDebug, "debug", false, "Print debug info to stderr")
+
+	addShowConfig(adoWorkItemUpdateCmd, workItemConfigSource, adoConfigSource, authConfigSource)
+}
+
+func runAdoWorkItemUpdate(cmd *cobra.Command, args []string) error {
+	opts := adoworkitem.UpdateOptions{
+		Ctx:         cmd.Context(),
+		WorkItemURL: args[0],
+		Changes:     adoWIUpdateChanges.changes(cmd),
+
+		DryRun:     adoWIUpdateDryRun,
+		OutputJSON: adoWIUpdateOutputJSON,
+		Debug:      adoWIUpdateDebug,
+		DebugLog: func(msg string) {
+			fmt.Fprintln(os.Stderr, msg)
+		},
+	}
+
+	result, err := adoworkitem.Update(opts)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(result.Output)
+	return nil
+}
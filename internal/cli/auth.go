@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage Azure DevOps credentials",
+	Long: `Manage Azure DevOps credentials.
+
+Credentials are looked up through a chain of providers, configured in
+~/.toolbox/auth.json. Without configuration the chain is:
+  1. env: AZDO_PAT or ADO_PAT
+  2. keyring: a PAT stored with "toolbox auth login"
+  3. azcli: a token from "az account get-access-token"
+
+Subcommands:
+  login   Store a PAT in the OS keyring
+  logout  Remove a stored PAT`,
+}
+
+// authHelp returns the "Auth:" section of an Azure DevOps command's help, listing the
+// PAT scopes the command needs.
+func authHelp(scopes string) string {
+	return `Auth:
+  Credentials come from the first of these that has them (see "toolbox auth"):
+  - AZDO_PAT or ADO_PAT
+  - a PAT stored with "toolbox auth login"
+  - Azure CLI login (dev.azure.com only)
+  PAT scopes: ` + scopes + `
+`
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Store an Azure DevOps PAT in the OS keyring",
+	Long: `Store an Azure DevOps Personal Access Token in the OS keyring (Secret Service
+via secret-tool on Linux, the login keychain on macOS).
+
+The PAT is read from stdin. With --org it is used only for that organization
+(or collection); otherwise it is used for every organization without its own PAT.
+
+Examples:
+  toolbox auth login
+  toolbox auth login --org contoso
+  pass show ado/contoso | toolbox auth login --org contoso`,
+	Args: cobra.NoArgs,
+	RunE: runAuthLogin,
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove an Azure DevOps PAT from the OS keyring",
+	Long: `Remove a PAT stored with "toolbox auth login".
+
+Examples:
+  toolbox auth logout
+  toolbox auth logout --org contoso`,
+	Args: cobra.NoArgs,
+	RunE: runAuthLogout,
+}
+
+var (
+	authLoginOrg  string
+	authLogoutOrg string
+)
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+
+	authLoginCmd.Flags().StringVar(&authLoginOrg, "org", "", "Organization (or collection) the PAT is for (default: all organizations)")
+	authLogoutCmd.Flags().StringVar(&authLogoutOrg, "org", "", "Organization (or collection) whose PAT to remove (default: the PAT for all organizations)")
+}
+
+func runAuthLogin(cmd *cobra.Command, args []string) error {
+	pat, err := readSecret("Azure DevOps PAT: ")
+	if err != nil {
+		return err
+	}
+	if pat == "" {
+		return errors.New("no PAT entered")
+	}
+
+	if err := auth.StorePAT(authLoginOrg, pat); err != nil {
+		return err
+	}
+
+	target := "all organizations"
+	if authLoginOrg != "" {
+		target = authLoginOrg
+	}
+	fmt.Fprintf(os.Stderr, "PAT stored for %s.\n", target)
+	return nil
+}
+
+func runAuthLogout(cmd *cobra.Command, args []string) error {
+	return auth.DeletePAT(authLogoutOrg)
+}
+
+// readSecret reads a line from stdin. When stdin is a terminal, it prompts and turns off
+// echo while the secret is typed (via stty, where available).
+func readSecret(prompt string) (string, error) {
+	info, err := os.Stdin.Stat()
+	interactive := err == nil && info.Mode()&os.ModeCharDevice != 0
+
+	if interactive {
+		fmt.Fprint(os.Stderr, prompt)
+		if runtime.GOOS != "windows" && setEcho(false) == nil {
+			defer func() {
+				_ = setEcho(true)
+				fmt.Fprintln(os.Stderr)
+			}()
+		}
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("read PAT: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+func setEcho(on bool) error {
+	mode := "-echo"
+	if on {
+		mode = "echo"
+	}
+	cmd := exec.Command("stty", mode)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
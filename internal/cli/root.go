@@ -1,19 +1,46 @@
 package cli
 
 import (
+	"errors"
+
 	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/config"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "toolbox",
 	Short: "AI-assisted software development tools",
-	Long:  `A collection of tools to assist in AI-enabled software development.`,
+	Long: `A collection of tools to assist in AI-enabled software development.
+
+Profiles:
+  Named profiles in ~/.toolbox/config.json group settings per organization.
+  A profile is selected with --profile or TOOLBOX_PROFILE, or otherwise
+  automatically from the organization in the URL.
+
+Repository config:
+  A .toolbox directory in the working directory or one of its parents is
+  layered over ~/.toolbox (flags > repository > user > defaults). Use
+  --show-config on a command to see the effective settings.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if rootProfile != "" {
+			config.SetProfile(rootProfile)
+		}
+	},
 }
 
+var rootProfile string
+
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if errors.Is(err, errConfigShown) {
+		return nil
+	}
+	return err
 }
 
 func init() {
 	rootCmd.AddCommand(versionCmd)
+
+	rootCmd.PersistentFlags().StringVar(&rootProfile, "profile", "", "Profile from ~/.toolbox/config.json to use (default: $TOOLBOX_PROFILE, or the one for the URL's organization)")
 }
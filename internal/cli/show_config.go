@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+	"github.com/krubenok/toolbox/internal/config"
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+// errConfigShown stops a command after --show-config has printed the config.
+var errConfigShown = errors.New("config shown")
+
+// configSource describes a config file a command reads, for --show-config.
+type configSource struct {
+	file     string
+	defaults func() any
+	// userOnly sources ignore the repository's .toolbox directory.
+	userOnly bool
+	// userKeys are top-level keys that are ignored in the repository's file.
+	userKeys []string
+	// noOrg sources are read before the organization is known, so only an explicitly
+	// selected profile applies.
+	noOrg bool
+	// flags maps a command flag to the dotted config path it overrides.
+	flags map[string]string
+}
+
+var (
+	adoConfigSource = configSource{
+		file:     "ado.json",
+		defaults: func() any { return &ado.Config{Retry: ado.DefaultRetryConfig()} },
+		userOnly: true,
+		noOrg:    true,
+	}
+	authConfigSource = configSource{
+		file:     "auth.json",
+		defaults: func() any { return &auth.Config{Providers: auth.DefaultProviders()} },
+		userOnly: true,
+	}
+	prCommentsConfigSource = configSource{
+		file: "ado-pr-comments.json",
+		defaults: func() any {
+			return &adoprcomments.Config{
+				Filter:   adoprcomments.DefaultFilterConfig(),
+				Output:   adoprcomments.DefaultOutputConfig(),
+				Status:   adoprcomments.DefaultStatusConfig(),
+				PROutput: adoprcomments.DefaultPROutputConfig(),
+			}
+		},
+	}
+	workItemConfigSource = configSource{
+		file:     "ado-work-item.json",
+		defaults: func() any { return &adoworkitem.Config{Output: adoworkitem.DefaultOutputConfig()} },
+		userKeys: []string{adoworkitem.AttachmentDownloadKey},
+	}
+)
+
+// addShowConfig adds a --show-config flag to cmd that prints the effective settings from
+// the given config files, and where each one comes from, instead of running the command.
+// Arguments and required flags are not enforced with --show-config; a URL argument or
+// --project-url, if given, selects the organization's profile.
+func addShowConfig(cmd *cobra.Command, sources ...configSource) {
+	var show bool
+	cmd.Flags().BoolVar(&show, "show-config", false, "Print the effective config and where each value comes from, then exit")
+
+	validateArgs := cmd.Args
+	cmd.Args = func(c *cobra.Command, args []string) error {
+		if show || validateArgs == nil {
+			return nil
+		}
+		return validateArgs(c, args)
+	}
+
+	// Runs before cobra checks required flags, so those need not be set.
+	cmd.PreRunE = func(c *cobra.Command, args []string) error {
+		if !show {
+			return nil
+		}
+		if err := printConfig(c, args, sources); err != nil {
+			return err
+		}
+		c.SilenceErrors = true
+		c.SilenceUsage = true
+		return errConfigShown
+	}
+}
+
+// printConfig writes the effective settings of each config file to stdout.
+func printConfig(cmd *cobra.Command, args []string, sources []configSource) error {
+	org := showConfigOrg(cmd, args)
+
+	repoDir, err := config.RepoDir()
+	if err != nil {
+		return err
+	}
+	if repoDir == "" {
+		repoDir = "(none)"
+	}
+	fmt.Printf("Repository config: %s\n", repoDir)
+	if org != "" {
+		fmt.Printf("Organization: %s\n", org)
+	}
+
+	for _, src := range sources {
+		sourceOrg := org
+		if src.noOrg {
+			sourceOrg = ""
+		}
+
+		var layers []config.Layer
+		if src.userOnly {
+			layers, err = config.UserLayers(sourceOrg, src.file)
+		} else {
+			layers, err = config.Layers(sourceOrg, src.file)
+		}
+		if err == nil && len(src.userKeys) > 0 {
+			layers, err = config.WithoutRepoKeys(layers, src.userKeys...)
+		}
+		if err != nil {
+			return err
+		}
+		flagLayers, err := src.flagLayers(cmd)
+		if err != nil {
+			return err
+		}
+		layers = append(layers, flagLayers...)
+
+		settings, err := config.Explain(src.defaults(), layers)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n%s\n", src.file)
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, s := range settings {
+			value, err := json.Marshal(s.Value)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\n", s.Path, value, s.Source)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flagLayers returns a layer for each of the source's flags set on the command line.
+// Flags take precedence over every config file.
+func (src configSource) flagLayers(cmd *cobra.Command) ([]config.Layer, error) {
+	var layers []config.Layer
+	for name, path := range src.flags {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || !f.Changed {
+			continue
+		}
+
+		var value any = f.Value.String()
+		if f.Value.Type() == "stringSlice" {
+			value, _ = cmd.Flags().GetStringSlice(name)
+		}
+
+		// Nest the value under its dotted path, e.g. status.include -> {"status":{"include":v}}.
+		keys := strings.Split(path, ".")
+		for i := len(keys) - 1; i >= 0; i-- {
+			value = map[string]any{keys[i]: value}
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, config.Layer{Source: "flag --" + name, Data: data})
+	}
+	return layers, nil
+}
+
+// showConfigOrg returns the organization from the URL argument or --project-url, if any.
+func showConfigOrg(cmd *cobra.Command, args []string) string {
+	candidates := args
+	if f := cmd.Flags().Lookup("project-url"); f != nil && f.Value.String() != "" {
+		candidates = append(candidates, f.Value.String())
+	}
+	for _, c := range candidates {
+		if server, _, err := ado.ParseURL(c); err == nil {
+			return server.Organization
+		}
+	}
+	return ""
+}
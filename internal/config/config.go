@@ -13,7 +13,7 @@ func Dir() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("resolve home dir: %w", err)
 	}
-	return filepath.Join(home, ".toolbox"), nil
+	return filepath.Join(home, dirName), nil
 }
 
 // Path returns the full path to a config file within ~/.toolbox.
@@ -25,19 +25,47 @@ func Path(filename string) (string, error) {
 	return filepath.Join(dir, filename), nil
 }
 
-// Load reads and unmarshals a JSON config file from ~/.toolbox.
-// Returns os.ErrNotExist if file doesn't exist.
+// Load reads and unmarshals a JSON config file from ~/.toolbox and the repository's
+// .toolbox directory, layered over the section for the file in the explicitly selected
+// profile, if any (see LoadForOrg). Returns os.ErrNotExist if none exists.
 func Load(filename string, v any) error {
-	path, err := Path(filename)
+	return LoadForOrg("", filename, v)
+}
+
+// LoadForOrg is like Load, but also selects a profile automatically from the organization
+// when none is selected explicitly. The layers are unmarshaled in order of precedence
+// (see Layers): the profile's section for the file, then ~/.toolbox/<filename>, then
+// .toolbox/<filename> in the repository, so keys set in a later layer win and keys it
+// leaves out keep earlier values. Returns os.ErrNotExist if none exists.
+func LoadForOrg(org, filename string, v any) error {
+	layers, err := Layers(org, filename)
 	if err != nil {
 		return err
 	}
+	return unmarshalLayers(layers, v)
+}
 
-	data, err := os.ReadFile(path)
+// LoadUserForOrg is like LoadForOrg but ignores the repository's .toolbox directory
+// (see UserLayers).
+func LoadUserForOrg(org, filename string, v any) error {
+	layers, err := UserLayers(org, filename)
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal(data, v)
+	return unmarshalLayers(layers, v)
+}
+
+// LoadForOrgUserKeys is like LoadForOrg, but the given top-level keys are only read
+// from the user layers (see WithoutRepoKeys).
+func LoadForOrgUserKeys(org, filename string, v any, userKeys ...string) error {
+	layers, err := Layers(org, filename)
+	if err != nil {
+		return err
+	}
+	if layers, err = WithoutRepoKeys(layers, userKeys...); err != nil {
+		return err
+	}
+	return unmarshalLayers(layers, v)
 }
 
 // Save marshals and writes a JSON config file to ~/.toolbox.
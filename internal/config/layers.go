@@ -0,0 +1,221 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// dirName is the name of the config directory, both in the home directory and in a repository.
+const dirName = ".toolbox"
+
+// Layer is one source of settings for a config file.
+type Layer struct {
+	// Source describes where the settings come from, e.g. `profile "contoso"`,
+	// "user /home/me/.toolbox/ado.json" or "repo /src/app/.toolbox/ado.json".
+	Source string
+	Data   json.RawMessage
+	// Repo is set for the file in the repository's .toolbox directory.
+	Repo bool
+}
+
+// RepoDir returns the repository-local .toolbox directory: the nearest one found walking
+// up from the working directory, stopping at the home directory (whose .toolbox is the
+// user config) or the filesystem root. Returns "" if there is none.
+func RepoDir() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("resolve working dir: %w", err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home dir: %w", err)
+	}
+
+	for dir := wd; dir != home; {
+		candidate := filepath.Join(dir, dirName)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return "", nil
+}
+
+// Layers returns the settings for a config file from lowest to highest precedence:
+// the active profile's section (see LoadForOrg), the file in ~/.toolbox, and the file
+// in the repository's .toolbox directory (see RepoDir). Missing layers are left out.
+func Layers(org, filename string) ([]Layer, error) {
+	return layers(org, filename, true)
+}
+
+// UserLayers is like Layers without the repository file, for settings that a checked-out
+// repository must not control, such as how credentials are obtained.
+func UserLayers(org, filename string) ([]Layer, error) {
+	return layers(org, filename, false)
+}
+
+func layers(org, filename string, repo bool) ([]Layer, error) {
+	var out []Layer
+
+	profile, section, err := profileSection(org, filename)
+	if err != nil {
+		return nil, err
+	}
+	if section != nil {
+		out = append(out, Layer{Source: fmt.Sprintf("profile %q", profile), Data: section})
+	}
+
+	path, err := Path(filename)
+	if err != nil {
+		return nil, err
+	}
+	if out, err = appendFileLayer(out, "user", path); err != nil {
+		return nil, err
+	}
+
+	if repo {
+		dir, err := RepoDir()
+		if err != nil {
+			return nil, err
+		}
+		if dir != "" {
+			if out, err = appendFileLayer(out, "repo", filepath.Join(dir, filename)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
+
+// WithoutRepoKeys returns layers with the given top-level keys removed from the
+// repository layer, for settings in a shared file that a checked-out repository must
+// not control.
+func WithoutRepoKeys(layers []Layer, keys ...string) ([]Layer, error) {
+	out := make([]Layer, 0, len(layers))
+	for _, l := range layers {
+		if !l.Repo {
+			out = append(out, l)
+			continue
+		}
+		var m map[string]json.RawMessage
+		if err := json.Unmarshal(l.Data, &m); err != nil {
+			return nil, fmt.Errorf("%s: %w", l.Source, err)
+		}
+		for _, k := range keys {
+			delete(m, k)
+		}
+		data, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		l.Data = data
+		out = append(out, l)
+	}
+	return out, nil
+}
+
+// appendFileLayer appends the file at path as a layer, if it exists.
+func appendFileLayer(out []Layer, kind, path string) ([]Layer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return out, nil
+		}
+		return nil, err
+	}
+	return append(out, Layer{Source: kind + " " + path, Data: data, Repo: kind == "repo"}), nil
+}
+
+// unmarshalLayers unmarshals each layer into v in order, so later layers override keys
+// set by earlier ones. Returns os.ErrNotExist if there are no layers.
+func unmarshalLayers(layers []Layer, v any) error {
+	if len(layers) == 0 {
+		return os.ErrNotExist
+	}
+	for _, l := range layers {
+		if err := json.Unmarshal(l.Data, v); err != nil {
+			return fmt.Errorf("%s: %w", l.Source, err)
+		}
+	}
+	return nil
+}
+
+// Setting is one effective config value and the layer it came from.
+type Setting struct {
+	Path   string // dotted key path, e.g. "status.include"
+	Value  any
+	Source string // Layer.Source, or "default"
+}
+
+// Explain merges layers the way they are unmarshaled (objects key by key, everything
+// else replaced) and returns every effective value with its source, sorted by path.
+// Defaults fill in top-level sections that no layer sets, matching how the tool
+// LoadConfig functions fall back to defaults for nil sections.
+func Explain(defaults any, layers []Layer) ([]Setting, error) {
+	settings := make(map[string]Setting)
+	set := make(map[string]bool)
+
+	for _, l := range layers {
+		var m map[string]any
+		if err := json.Unmarshal(l.Data, &m); err != nil {
+			return nil, fmt.Errorf("%s: %w", l.Source, err)
+		}
+		for key := range m {
+			set[key] = true
+		}
+		mergeSettings(settings, "", m, l.Source)
+	}
+
+	if defaults != nil {
+		data, err := json.Marshal(defaults)
+		if err != nil {
+			return nil, err
+		}
+		var m map[string]any
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, err
+		}
+		for key := range set {
+			delete(m, key)
+		}
+		mergeSettings(settings, "", m, "default")
+	}
+
+	out := make([]Setting, 0, len(settings))
+	for _, s := range settings {
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out, nil
+}
+
+// mergeSettings records the values in m under prefix, replacing any value they override.
+func mergeSettings(settings map[string]Setting, prefix string, m map[string]any, source string) {
+	for key, value := range m {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		// An object merges into what is there; anything else replaces the whole subtree.
+		if obj, ok := value.(map[string]any); ok {
+			delete(settings, path)
+			mergeSettings(settings, path, obj, source)
+			continue
+		}
+		for p := range settings {
+			if strings.HasPrefix(p, path+".") {
+				delete(settings, p)
+			}
+		}
+		settings[path] = Setting{Path: path, Value: value, Source: source}
+	}
+}
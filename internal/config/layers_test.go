@@ -0,0 +1,155 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// setupRepo creates a repository with the given .toolbox files outside the home
+// directory and makes a subdirectory of it the working directory.
+func setupRepo(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	root := t.TempDir()
+	dir := filepath.Join(root, ".toolbox")
+	sub := filepath.Join(root, "src", "pkg")
+	for _, d := range []string{dir, sub} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(sub)
+}
+
+func TestRepoDir(t *testing.T) {
+	home := setupHome(t, nil)
+
+	dir, err := RepoDir()
+	if err != nil {
+		t.Fatalf("RepoDir: %v", err)
+	}
+	if dir != "" {
+		t.Fatalf("RepoDir() in home = %q, want none (~/.toolbox is the user config)", dir)
+	}
+
+	// A repository under the home directory is found; ~/.toolbox above it is not.
+	sub := filepath.Join(home, "repo", "src")
+	if err := os.MkdirAll(filepath.Join(home, "repo", ".toolbox"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(sub)
+	dir, err = RepoDir()
+	if err != nil {
+		t.Fatalf("RepoDir: %v", err)
+	}
+	if filepath.Base(filepath.Dir(dir)) != "repo" {
+		t.Fatalf("RepoDir() = %q, want repo/.toolbox", dir)
+	}
+}
+
+func TestLoadForOrgLayersRepoOverUser(t *testing.T) {
+	setupHome(t, map[string]string{
+		"config.json": testProfiles,
+		"tool.json":   `{"output": {"b": "always", "c": "always"}}`,
+	})
+	setupRepo(t, map[string]string{
+		"tool.json": `{"output": {"c": "never"}, "status": {"include": ["fixed"]}}`,
+	})
+
+	var cfg testConfig
+	if err := LoadForOrg("contoso-dev", "tool.json", &cfg); err != nil {
+		t.Fatalf("LoadForOrg: %v", err)
+	}
+	want := map[string]string{"a": "never", "b": "always", "c": "never"}
+	if !reflect.DeepEqual(cfg.Output, want) {
+		t.Fatalf("output = %v, want %v", cfg.Output, want)
+	}
+	if cfg.Status == nil || !reflect.DeepEqual(cfg.Status.Include, []string{"fixed"}) {
+		t.Fatalf("status = %+v, want repo value", cfg.Status)
+	}
+
+	// User-only settings ignore the repository file.
+	cfg = testConfig{}
+	if err := LoadUserForOrg("contoso-dev", "tool.json", &cfg); err != nil {
+		t.Fatalf("LoadUserForOrg: %v", err)
+	}
+	if cfg.Output["c"] != "always" || cfg.Status.Include[0] != "active" {
+		t.Fatalf("user config = %+v, want no repo values", cfg)
+	}
+}
+
+func TestLoadForOrgUserKeys(t *testing.T) {
+	setupHome(t, map[string]string{
+		"tool.json": `{"output": {"a": "always"}}`,
+	})
+	setupRepo(t, map[string]string{
+		"tool.json": `{"output": {"b": "never"}, "status": {"include": ["fixed"]}}`,
+	})
+
+	var cfg testConfig
+	if err := LoadForOrgUserKeys("", "tool.json", &cfg, "status"); err != nil {
+		t.Fatalf("LoadForOrgUserKeys: %v", err)
+	}
+	want := map[string]string{"a": "always", "b": "never"}
+	if !reflect.DeepEqual(cfg.Output, want) {
+		t.Fatalf("output = %v, want %v", cfg.Output, want)
+	}
+	if cfg.Status != nil {
+		t.Fatalf("status = %+v, want none from the repository", cfg.Status)
+	}
+}
+
+func TestLoadRepoOnly(t *testing.T) {
+	setupHome(t, nil)
+	setupRepo(t, map[string]string{"tool.json": `{"output": {"a": "always"}}`})
+
+	var cfg testConfig
+	if err := Load("tool.json", &cfg); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Output["a"] != "always" {
+		t.Fatalf("output = %v, want repo value", cfg.Output)
+	}
+
+	if err := LoadUserForOrg("", "tool.json", &cfg); !os.IsNotExist(err) {
+		t.Fatalf("LoadUserForOrg err = %v, want not exist", err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	defaults := map[string]any{
+		"retry":  map[string]any{"maxRetries": 4, "maxDelayMs": 30000},
+		"output": map[string]any{"a": "notEmpty", "b": "notEmpty"},
+	}
+	layers := []Layer{
+		{Source: "profile", Data: []byte(`{"output": {"a": "never", "b": "never"}, "fields": ["x"]}`)},
+		{Source: "user", Data: []byte(`{"output": {"b": "always"}, "nested": {"deep": {"k": 1}}}`)},
+		{Source: "repo", Data: []byte(`{"fields": ["y", "z"], "nested": {"deep": true}}`)},
+	}
+
+	got, err := Explain(defaults, layers)
+	if err != nil {
+		t.Fatalf("Explain: %v", err)
+	}
+	want := []Setting{
+		{Path: "fields", Value: []any{"y", "z"}, Source: "repo"},
+		{Path: "nested.deep", Value: true, Source: "repo"},
+		{Path: "output.a", Value: "never", Source: "profile"},
+		{Path: "output.b", Value: "always", Source: "user"},
+		{Path: "retry.maxDelayMs", Value: float64(30000), Source: "default"},
+		{Path: "retry.maxRetries", Value: float64(4), Source: "default"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Explain() =\n%+v\nwant\n%+v", got, want)
+	}
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// profilesFile holds named profiles in ~/.toolbox.
+	profilesFile = "config.json"
+
+	// ProfileEnv names the environment variable that selects a profile.
+	ProfileEnv = "TOOLBOX_PROFILE"
+)
+
+// Profile is a named set of settings in ~/.toolbox/config.json. Each key other than
+// "organizations" is a section holding the same settings as the tool config file of that
+// name, e.g. "auth" for auth.json or "ado-pr-comments" for ado-pr-comments.json.
+type Profile struct {
+	// Organizations selects the profile automatically for these organizations (or
+	// collections). A profile is also selected for the organization matching its name.
+	Organizations []string
+	Sections      map[string]json.RawMessage
+}
+
+// UnmarshalJSON splits the organizations list from the tool sections.
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if orgs, ok := raw["organizations"]; ok {
+		if err := json.Unmarshal(orgs, &p.Organizations); err != nil {
+			return fmt.Errorf("organizations: %w", err)
+		}
+		delete(raw, "organizations")
+	}
+	p.Sections = raw
+	return nil
+}
+
+// Profiles is the content of ~/.toolbox/config.json.
+type Profiles struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+var selected struct {
+	mu   sync.Mutex
+	name string
+}
+
+// SetProfile selects a profile by name for the rest of the process (e.g. from --profile),
+// overriding TOOLBOX_PROFILE and selection by organization. Empty clears the selection.
+func SetProfile(name string) {
+	selected.mu.Lock()
+	defer selected.mu.Unlock()
+	selected.name = name
+}
+
+// explicitProfile returns the profile selected with SetProfile or TOOLBOX_PROFILE.
+func explicitProfile() string {
+	selected.mu.Lock()
+	defer selected.mu.Unlock()
+	if selected.name != "" {
+		return selected.name
+	}
+	return strings.TrimSpace(os.Getenv(ProfileEnv))
+}
+
+// LoadProfiles reads ~/.toolbox/config.json. A missing file yields no profiles.
+func LoadProfiles() (*Profiles, error) {
+	var p Profiles
+	path, err := Path(profilesFile)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &p, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", profilesFile, err)
+	}
+	return &p, nil
+}
+
+// Select returns the name of the active profile: the explicitly selected one, otherwise
+// the one for the organization, otherwise "" (no profile). Selecting a profile that does
+// not exist is an error.
+func (p *Profiles) Select(org string) (string, error) {
+	if name := explicitProfile(); name != "" {
+		if _, ok := p.Profiles[name]; !ok {
+			return "", fmt.Errorf("profile %q not found in ~/.toolbox/%s", name, profilesFile)
+		}
+		return name, nil
+	}
+	if org == "" {
+		return "", nil
+	}
+
+	// Sorted so the choice is stable when several profiles claim the organization.
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.EqualFold(name, org) || slices.ContainsFunc(p.Profiles[name].Organizations, func(o string) bool {
+			return strings.EqualFold(o, org)
+		}) {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// profileSection returns the name of the active profile and its section for a config
+// file, or a nil section if there is none.
+func profileSection(org, filename string) (string, json.RawMessage, error) {
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return "", nil, err
+	}
+	name, err := profiles.Select(org)
+	if err != nil || name == "" {
+		return "", nil, err
+	}
+	return name, profiles.Profiles[name].Sections[sectionName(filename)], nil
+}
+
+// sectionName is the profile key for a config file: its name without ".json".
+func sectionName(filename string) string {
+	return strings.TrimSuffix(filename, ".json")
+}
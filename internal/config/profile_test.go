@@ -0,0 +1,128 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupHome points the home directory at a temp dir holding the given ~/.toolbox files
+// and makes it the working directory, so no repository config is found. Returns the
+// home directory.
+func setupHome(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(ProfileEnv, "")
+	t.Chdir(home)
+
+	dir := filepath.Join(home, ".toolbox")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return home
+}
+
+type testConfig struct {
+	Status *struct {
+		Include []string `json:"include"`
+	} `json:"status"`
+	Output map[string]string `json:"output"`
+}
+
+const testProfiles = `{
+  "profiles": {
+    "contoso": {
+      "organizations": ["contoso-dev"],
+      "tool": {"status": {"include": ["active"]}, "output": {"a": "never", "b": "never"}}
+    },
+    "fabrikam": {
+      "tool": {"status": {"include": ["pending"]}}
+    }
+  }
+}`
+
+func TestLoadForOrgLayersToolFileOverProfile(t *testing.T) {
+	setupHome(t, map[string]string{
+		"config.json": testProfiles,
+		"tool.json":   `{"output": {"b": "always"}}`,
+	})
+
+	var cfg testConfig
+	if err := LoadForOrg("Contoso-Dev", "tool.json", &cfg); err != nil {
+		t.Fatalf("LoadForOrg: %v", err)
+	}
+	if cfg.Status == nil || len(cfg.Status.Include) != 1 || cfg.Status.Include[0] != "active" {
+		t.Fatalf("status = %+v, want profile value", cfg.Status)
+	}
+	if cfg.Output["a"] != "never" || cfg.Output["b"] != "always" {
+		t.Fatalf("output = %v, want a from profile and b from tool file", cfg.Output)
+	}
+}
+
+func TestLoadForOrgProfileSelection(t *testing.T) {
+	setupHome(t, map[string]string{"config.json": testProfiles})
+
+	tests := []struct {
+		name     string
+		org      string
+		env      string
+		want     string
+		wantErr  bool
+		notExist bool
+	}{
+		{name: "by profile name", org: "fabrikam", want: "pending"},
+		{name: "by organizations list", org: "contoso-dev", want: "active"},
+		{name: "env wins over org", org: "fabrikam", env: "contoso", want: "active"},
+		{name: "no matching profile and no tool file", org: "other", notExist: true},
+		{name: "unknown profile", env: "missing", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProfileEnv, tt.env)
+
+			var cfg testConfig
+			err := LoadForOrg(tt.org, "tool.json", &cfg)
+			switch {
+			case tt.notExist:
+				if !os.IsNotExist(err) {
+					t.Fatalf("err = %v, want not exist", err)
+				}
+				return
+			case tt.wantErr:
+				if err == nil {
+					t.Fatal("err = nil, want error")
+				}
+				return
+			case err != nil:
+				t.Fatalf("LoadForOrg: %v", err)
+			}
+			if cfg.Status == nil || cfg.Status.Include[0] != tt.want {
+				t.Fatalf("status = %+v, want %q", cfg.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetProfileOverridesEnv(t *testing.T) {
+	setupHome(t, map[string]string{"config.json": testProfiles})
+	t.Setenv(ProfileEnv, "contoso")
+	SetProfile("fabrikam")
+	t.Cleanup(func() { SetProfile("") })
+
+	var cfg testConfig
+	if err := Load("tool.json", &cfg); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Status == nil || cfg.Status.Include[0] != "pending" {
+		t.Fatalf("status = %+v, want fabrikam profile", cfg.Status)
+	}
+}
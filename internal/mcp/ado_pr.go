@@ -0,0 +1,51 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdoPRInput defines the input schema for the ado_pr tool.
+type AdoPRInput struct {
+	// Azure DevOps PR URL (required)
+	PRURL string `json:"pr_url" jsonschema:"Azure DevOps PR URL"`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// registerAdoPRTool registers the ado_pr tool with the server.
+func registerAdoPRTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_pr",
+		Description:  "Fetch pull request details from Azure DevOps. Returns title, description, author, source/target branches, merge status, draft flag, reviewers with their votes, and linked work item IDs.",
+		OutputSchema: outputSchema[adoprcomments.SimplifiedPR](),
+	}, handleAdoPR)
+}
+
+// handleAdoPR handles the ado_pr tool invocation.
+func handleAdoPR(ctx context.Context, req *mcp.CallToolRequest, input AdoPRInput) (*mcp.CallToolResult, adoprcomments.SimplifiedPR, error) {
+	if input.PRURL == "" {
+		return nil, adoprcomments.SimplifiedPR{}, errors.New("error: pr_url is required")
+	}
+
+	opts := adoprcomments.PROptions{
+		Ctx:        ctx,
+		PRURL:      input.PRURL,
+		OutputJSON: input.Format == "json",
+	}
+
+	result, err := adoprcomments.GetPR(opts)
+	if err != nil {
+		return nil, adoprcomments.SimplifiedPR{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, result.PR, nil
+}
@@ -2,6 +2,8 @@ package mcp
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -17,25 +19,28 @@ type AdoPRCommentsInput struct {
 	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
 	// Disable content filtering
 	NoFilter bool `json:"no_filter,omitempty" jsonschema:"Response is filtered by default to remove null or emtpy fiels and other low-value data. Set no_filter to true to disable this behavior."`
+	// Attach code context to file-anchored threads
+	ContextLines *int `json:"context_lines,omitempty" jsonschema:"When set, each file-anchored thread includes the commented code plus this many lines above and below it (0 = only the commented lines). Omit to skip fetching code."`
+}
+
+// AdoPRCommentsOutput is the structured content of an ado_pr_comments result.
+type AdoPRCommentsOutput struct {
+	Threads []adoprcomments.SimplifiedThread `json:"threads"`
 }
 
 // registerAdoPRCommentsTool registers the ado_pr_comments tool with the server.
 func registerAdoPRCommentsTool(server *mcp.Server) {
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "ado_pr_comments",
-		Description: "Fetch pull request comments from Azure DevOps. Returns comment threads with author, content, status, and file location information. By default, returns only comments with status 'actvive'.",
+		Name:         "ado_pr_comments",
+		Description:  "Fetch pull request comments from Azure DevOps. Returns comment threads with author, content, status, and file location information. By default, returns only comments with status 'actvive'.",
+		OutputSchema: outputSchema[AdoPRCommentsOutput](),
 	}, handleAdoPRComments)
 }
 
 // handleAdoPRComments handles the ado_pr_comments tool invocation.
-func handleAdoPRComments(ctx context.Context, req *mcp.CallToolRequest, input AdoPRCommentsInput) (*mcp.CallToolResult, any, error) {
+func handleAdoPRComments(ctx context.Context, req *mcp.CallToolRequest, input AdoPRCommentsInput) (*mcp.CallToolResult, AdoPRCommentsOutput, error) {
 	if input.PRURL == "" {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "error: pr_url is required"},
-			},
-			IsError: true,
-		}, nil, nil
+		return nil, AdoPRCommentsOutput{}, errors.New("error: pr_url is required")
 	}
 
 	opts := adoprcomments.Options{
@@ -45,15 +50,14 @@ func handleAdoPRComments(ctx context.Context, req *mcp.CallToolRequest, input Ad
 		OutputJSON: input.Format == "json",
 		NoFilter:   input.NoFilter,
 	}
+	if input.ContextLines != nil {
+		opts.IncludeCodeContext = true
+		opts.ContextLines = *input.ContextLines
+	}
 
 	result, err := adoprcomments.Run(opts)
 	if err != nil {
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				&mcp.TextContent{Text: "error: " + err.Error()},
-			},
-			IsError: true,
-		}, nil, nil
+		return nil, AdoPRCommentsOutput{}, fmt.Errorf("error: %w", err)
 	}
 
 	contents := make([]mcp.Content, 0, 2)
@@ -64,5 +68,5 @@ func handleAdoPRComments(ctx context.Context, req *mcp.CallToolRequest, input Ad
 
 	return &mcp.CallToolResult{
 		Content: contents,
-	}, nil, nil
+	}, AdoPRCommentsOutput{Threads: result.Threads}, nil
 }
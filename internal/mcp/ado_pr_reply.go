@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdoPRReplyInput defines the input schema for the ado_pr_reply tool.
+type AdoPRReplyInput struct {
+	// Azure DevOps PR URL (required)
+	PRURL string `json:"pr_url" jsonschema:"Azure DevOps PR URL"`
+	// Thread to reply to (required)
+	ThreadID int `json:"thread_id" jsonschema:"ID of the comment thread to reply to (the thread 'id' from ado_pr_comments)."`
+	// Comment being replied to (optional)
+	ParentCommentID int `json:"parent_comment_id,omitempty" jsonschema:"Optional ID of the comment in the thread being replied to (the comment 'id' from ado_pr_comments)."`
+	// Reply text (required)
+	Message string `json:"message" jsonschema:"Reply text. Markdown is supported."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// registerAdoPRReplyTool registers the ado_pr_reply tool with the server.
+func registerAdoPRReplyTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_pr_reply",
+		Description:  "Reply to an existing Azure DevOps pull request comment thread. Use the thread 'id' returned by ado_pr_comments. Returns the created comment.",
+		OutputSchema: outputSchema[adoprcomments.ReplyResult](),
+	}, handleAdoPRReply)
+}
+
+// handleAdoPRReply handles the ado_pr_reply tool invocation.
+func handleAdoPRReply(ctx context.Context, req *mcp.CallToolRequest, input AdoPRReplyInput) (*mcp.CallToolResult, adoprcomments.ReplyResult, error) {
+	if input.PRURL == "" {
+		return nil, adoprcomments.ReplyResult{}, errors.New("error: pr_url is required")
+	}
+
+	opts := adoprcomments.ReplyOptions{
+		Ctx:             ctx,
+		PRURL:           input.PRURL,
+		ThreadID:        input.ThreadID,
+		ParentCommentID: input.ParentCommentID,
+		Message:         input.Message,
+		OutputJSON:      input.Format == "json",
+	}
+
+	result, err := adoprcomments.Reply(opts)
+	if err != nil {
+		return nil, adoprcomments.ReplyResult{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, *result, nil
+}
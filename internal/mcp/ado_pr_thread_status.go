@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdoPRThreadStatusInput defines the input schema for the ado_pr_thread_status tool.
+type AdoPRThreadStatusInput struct {
+	// Azure DevOps PR URL (required)
+	PRURL string `json:"pr_url" jsonschema:"Azure DevOps PR URL"`
+	// Thread to update (required)
+	ThreadID int `json:"thread_id" jsonschema:"ID of the comment thread to update (the thread 'id' from ado_pr_comments)."`
+	// New status (required)
+	Status string `json:"status" jsonschema:"New thread status. Values: active/fixed/closed/byDesign/pending/wontFix."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+	// Disable content filtering
+	NoFilter bool `json:"no_filter,omitempty" jsonschema:"Set no_filter to true to disable content filtering of the returned thread."`
+}
+
+// registerAdoPRThreadStatusTool registers the ado_pr_thread_status tool with the server.
+func registerAdoPRThreadStatusTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_pr_thread_status",
+		Description:  "Change the status of an Azure DevOps pull request comment thread, for example mark it 'fixed' after addressing the feedback. Use the thread 'id' returned by ado_pr_comments. Returns the updated thread.",
+		OutputSchema: outputSchema[adoprcomments.SimplifiedThread](),
+	}, handleAdoPRThreadStatus)
+}
+
+// handleAdoPRThreadStatus handles the ado_pr_thread_status tool invocation.
+func handleAdoPRThreadStatus(ctx context.Context, req *mcp.CallToolRequest, input AdoPRThreadStatusInput) (*mcp.CallToolResult, adoprcomments.SimplifiedThread, error) {
+	if input.PRURL == "" {
+		return nil, adoprcomments.SimplifiedThread{}, errors.New("error: pr_url is required")
+	}
+
+	opts := adoprcomments.SetStatusOptions{
+		Ctx:        ctx,
+		PRURL:      input.PRURL,
+		ThreadID:   input.ThreadID,
+		Status:     input.Status,
+		OutputJSON: input.Format == "json",
+		NoFilter:   input.NoFilter,
+	}
+
+	result, err := adoprcomments.SetStatus(opts)
+	if err != nil {
+		return nil, adoprcomments.SimplifiedThread{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, result.Thread, nil
+}
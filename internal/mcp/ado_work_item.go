@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdoWorkItemInput defines the input schema for the ado_work_item tool.
+type AdoWorkItemInput struct {
+	// Azure DevOps work item URL (required)
+	WorkItemURL string `json:"work_item_url" jsonschema:"Azure DevOps work item URL"`
+	// Section toggles (all sections are included by default)
+	NoDescription bool `json:"no_description,omitempty" jsonschema:"Set to true to omit the work item description."`
+	NoDiscussion  bool `json:"no_discussion,omitempty" jsonschema:"Set to true to omit the work item discussion (comments)."`
+	NoChildren    bool `json:"no_children,omitempty" jsonschema:"Set to true to omit child work item links."`
+	NoLinks       bool `json:"no_links,omitempty" jsonschema:"Set to true to omit parent, related, predecessor/successor and artifact (PR, commit, build) links."`
+	NoAttachments bool `json:"no_attachments,omitempty" jsonschema:"Set to true to omit attachment links."`
+	// Attachment downloads
+	DownloadAttachments bool `json:"download_attachments,omitempty" jsonschema:"Set to true to download attachments. Small text, log and JSON attachments are inlined and image attachments are returned as image content."`
+	// Maximum number of discussion comments to fetch
+	MaxComments int `json:"max_comments,omitempty" jsonschema:"Maximum number of discussion comments to fetch. 0 or omitted means no limit."`
+	// Additional fields by reference name
+	Fields []string `json:"fields,omitempty" jsonschema:"Additional field reference names to include, e.g. System.AreaPath, System.Tags, Microsoft.VSTS.Common.Priority or custom fields like Custom.Team."`
+	// Child hierarchy depth
+	Depth    int `json:"depth,omitempty" jsonschema:"Resolve child links into a nested tree this many levels deep, with title, type, state and assignee for each child. 0 or omitted returns direct child links only. Max 10."`
+	MaxNodes int `json:"max_nodes,omitempty" jsonschema:"Maximum number of descendants to fetch when depth is set. Defaults to 500."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// registerAdoWorkItemTool registers the ado_work_item tool with the server.
+func registerAdoWorkItemTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_work_item",
+		Description:  "Fetch work item details from Azure DevOps. Returns title, type, state, assignee, description, discussion comments, child links (optionally resolved into a nested tree with depth), parent/related/dependency links, linked pull requests, commits and builds, and attachment links. With download_attachments, small text attachments are inlined and images are returned as image content.",
+		OutputSchema: outputSchema[adoworkitem.SimplifiedWorkItem](),
+	}, handleAdoWorkItem)
+}
+
+// handleAdoWorkItem handles the ado_work_item tool invocation.
+func handleAdoWorkItem(ctx context.Context, req *mcp.CallToolRequest, input AdoWorkItemInput) (*mcp.CallToolResult, adoworkitem.SimplifiedWorkItem, error) {
+	if input.WorkItemURL == "" {
+		return nil, adoworkitem.SimplifiedWorkItem{}, errors.New("error: work_item_url is required")
+	}
+
+	opts := adoworkitem.Options{
+		Ctx:         ctx,
+		WorkItemURL: input.WorkItemURL,
+
+		IncludeDescription:  !input.NoDescription,
+		IncludeDiscussion:   !input.NoDiscussion,
+		IncludeChildren:     !input.NoChildren,
+		IncludeLinks:        !input.NoLinks,
+		IncludeAttachments:  !input.NoAttachments,
+		MaxComments:         input.MaxComments,
+		DownloadAttachments: input.DownloadAttachments,
+		Fields:              input.Fields,
+		ChildDepth:          input.Depth,
+		MaxChildNodes:       input.MaxNodes,
+
+		OutputJSON: input.Format == "json",
+	}
+
+	result, err := adoworkitem.Run(opts)
+	if err != nil {
+		return nil, adoworkitem.SimplifiedWorkItem{}, fmt.Errorf("error: %w", err)
+	}
+
+	content := []mcp.Content{
+		&mcp.TextContent{Text: result.Output},
+	}
+	for _, img := range result.Images {
+		content = append(content, &mcp.ImageContent{Data: img.Data, MIMEType: img.MIMEType})
+	}
+
+	return &mcp.CallToolResult{
+		Content: content,
+	}, result.WorkItem, nil
+}
@@ -0,0 +1,54 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdoWorkItemCommentInput defines the input schema for the ado_work_item_comment tool.
+type AdoWorkItemCommentInput struct {
+	// Azure DevOps work item URL (required)
+	WorkItemURL string `json:"work_item_url" jsonschema:"Azure DevOps work item URL"`
+	// Comment text (required)
+	Message string `json:"message" jsonschema:"Comment text in markdown. Mention users by unique name as @<unique name> or @user@domain.com."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// registerAdoWorkItemCommentTool registers the ado_work_item_comment tool with the server.
+func registerAdoWorkItemCommentTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_work_item_comment",
+		Description:  "Post a markdown comment to an Azure DevOps work item's discussion, with @mentions by unique name (email). Returns the created comment.",
+		OutputSchema: outputSchema[adoworkitem.AddCommentResult](),
+	}, handleAdoWorkItemComment)
+}
+
+// handleAdoWorkItemComment handles the ado_work_item_comment tool invocation.
+func handleAdoWorkItemComment(ctx context.Context, req *mcp.CallToolRequest, input AdoWorkItemCommentInput) (*mcp.CallToolResult, adoworkitem.AddCommentResult, error) {
+	if input.WorkItemURL == "" || input.Message == "" {
+		return nil, adoworkitem.AddCommentResult{}, errors.New("error: work_item_url and message are required")
+	}
+
+	opts := adoworkitem.AddCommentOptions{
+		Ctx:         ctx,
+		WorkItemURL: input.WorkItemURL,
+		Message:     input.Message,
+		OutputJSON:  input.Format == "json",
+	}
+
+	result, err := adoworkitem.AddComment(opts)
+	if err != nil {
+		return nil, adoworkitem.AddCommentResult{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, *result, nil
+}
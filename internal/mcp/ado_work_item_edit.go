@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// WorkItemChangesInput defines the fields shared by the work item create and update tools.
+type WorkItemChangesInput struct {
+	Title         string   `json:"title,omitempty" jsonschema:"Work item title."`
+	Description   string   `json:"description,omitempty" jsonschema:"Description in markdown. It is converted to HTML."`
+	State         string   `json:"state,omitempty" jsonschema:"State, e.g. New, Active, Resolved or Closed."`
+	AssignedTo    string   `json:"assigned_to,omitempty" jsonschema:"Assignee display name or email."`
+	AreaPath      string   `json:"area_path,omitempty" jsonschema:"Area path, e.g. Project\\Team."`
+	IterationPath string   `json:"iteration_path,omitempty" jsonschema:"Iteration path, e.g. Project\\Sprint 12."`
+	Tags          []string `json:"tags,omitempty" jsonschema:"Tags. Replaces all existing tags."`
+	Parent        string   `json:"parent,omitempty" jsonschema:"Parent work item ID or URL to link to."`
+}
+
+func (in WorkItemChangesInput) changes() adoworkitem.WorkItemChanges {
+	return adoworkitem.WorkItemChanges{
+		Title:         in.Title,
+		Description:   in.Description,
+		State:         in.State,
+		AssignedTo:    in.AssignedTo,
+		AreaPath:      in.AreaPath,
+		IterationPath: in.IterationPath,
+		Tags:          in.Tags,
+		Parent:        in.Parent,
+	}
+}
+
+// AdoWorkItemCreateInput defines the input schema for the ado_work_item_create tool.
+type AdoWorkItemCreateInput struct {
+	// Project URL (required)
+	ProjectURL string `json:"project_url" jsonschema:"Any Azure DevOps URL inside the project (project home, board, or work item URL)."`
+	// Work item type (required)
+	Type string `json:"type" jsonschema:"Work item type, e.g. Bug, Task or User Story."`
+	WorkItemChangesInput
+	// Dry run
+	DryRun bool `json:"dry_run,omitempty" jsonschema:"Set to true to return the JSON Patch document without creating the work item."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// AdoWorkItemUpdateInput defines the input schema for the ado_work_item_update tool.
+type AdoWorkItemUpdateInput struct {
+	// Azure DevOps work item URL (required)
+	WorkItemURL string `json:"work_item_url" jsonschema:"Azure DevOps work item URL"`
+	WorkItemChangesInput
+	// Dry run
+	DryRun bool `json:"dry_run,omitempty" jsonschema:"Set to true to return the JSON Patch document without updating the work item."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// AdoWorkItemEditOutput is the structured content of an ado_work_item_create or
+// ado_work_item_update result. WorkItem is omitted for dry runs.
+type AdoWorkItemEditOutput struct {
+	WorkItem *adoworkitem.SimplifiedWorkItem `json:"workItem,omitempty"`
+	Patch    []adoworkitem.PatchOperation    `json:"patch"`
+}
+
+// registerAdoWorkItemEditTools registers the ado_work_item_create and ado_work_item_update tools with the server.
+func registerAdoWorkItemEditTools(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_work_item_create",
+		Description:  "Create an Azure DevOps work item (e.g. file a follow-up bug). Sets title, markdown description, state, assignee, area/iteration path, tags and an optional parent link. Returns the created work item.",
+		OutputSchema: outputSchema[AdoWorkItemEditOutput](),
+	}, handleAdoWorkItemCreate)
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_work_item_update",
+		Description:  "Update fields of an existing Azure DevOps work item (e.g. move it to Resolved or reassign it). Only the given fields are changed; tags replace existing tags. Returns the updated work item.",
+		OutputSchema: outputSchema[AdoWorkItemEditOutput](),
+	}, handleAdoWorkItemUpdate)
+}
+
+// handleAdoWorkItemCreate handles the ado_work_item_create tool invocation.
+func handleAdoWorkItemCreate(ctx context.Context, req *mcp.CallToolRequest, input AdoWorkItemCreateInput) (*mcp.CallToolResult, AdoWorkItemEditOutput, error) {
+	if input.ProjectURL == "" || input.Type == "" || input.Title == "" {
+		return nil, AdoWorkItemEditOutput{}, errors.New("error: project_url, type and title are required")
+	}
+
+	opts := adoworkitem.CreateOptions{
+		Ctx:        ctx,
+		ProjectURL: input.ProjectURL,
+		Type:       input.Type,
+		Changes:    input.changes(),
+		DryRun:     input.DryRun,
+		OutputJSON: input.Format == "json",
+	}
+
+	result, err := adoworkitem.Create(opts)
+	if err != nil {
+		return nil, AdoWorkItemEditOutput{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, AdoWorkItemEditOutput{WorkItem: result.WorkItem, Patch: result.Patch}, nil
+}
+
+// handleAdoWorkItemUpdate handles the ado_work_item_update tool invocation.
+func handleAdoWorkItemUpdate(ctx context.Context, req *mcp.CallToolRequest, input AdoWorkItemUpdateInput) (*mcp.CallToolResult, AdoWorkItemEditOutput, error) {
+	if input.WorkItemURL == "" {
+		return nil, AdoWorkItemEditOutput{}, errors.New("error: work_item_url is required")
+	}
+
+	opts := adoworkitem.UpdateOptions{
+		Ctx:         ctx,
+		WorkItemURL: input.WorkItemURL,
+		Changes:     input.changes(),
+		DryRun:      input.DryRun,
+		OutputJSON:  input.Format == "json",
+	}
+
+	result, err := adoworkitem.Update(opts)
+	if err != nil {
+		return nil, AdoWorkItemEditOutput{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, AdoWorkItemEditOutput{WorkItem: result.WorkItem, Patch: result.Patch}, nil
+}
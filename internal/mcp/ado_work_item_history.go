@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdoWorkItemHistoryInput defines the input schema for the ado_work_item_history tool.
+type AdoWorkItemHistoryInput struct {
+	// Azure DevOps work item URL (required)
+	WorkItemURL string `json:"work_item_url" jsonschema:"Azure DevOps work item URL"`
+	// Field filter
+	Fields []string `json:"fields,omitempty" jsonschema:"Only show changes to these field reference names, e.g. System.State, System.AssignedTo. Omit to show all fields and link changes."`
+	// Revision limit
+	MaxUpdates int `json:"max_updates,omitempty" jsonschema:"Show only the most recent revisions left after filtering, up to this many. 0 or omitted means no limit."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// registerAdoWorkItemHistoryTool registers the ado_work_item_history tool with the server.
+func registerAdoWorkItemHistoryTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_work_item_history",
+		Description:  "Fetch the revision history of an Azure DevOps work item. Returns a timeline of who changed which field, when, and from what to what (state transitions, reassignments, description edits as line diffs), plus links added or removed.",
+		OutputSchema: outputSchema[adoworkitem.SimplifiedHistory](),
+	}, handleAdoWorkItemHistory)
+}
+
+// handleAdoWorkItemHistory handles the ado_work_item_history tool invocation.
+func handleAdoWorkItemHistory(ctx context.Context, req *mcp.CallToolRequest, input AdoWorkItemHistoryInput) (*mcp.CallToolResult, adoworkitem.SimplifiedHistory, error) {
+	if input.WorkItemURL == "" {
+		return nil, adoworkitem.SimplifiedHistory{}, errors.New("error: work_item_url is required")
+	}
+
+	opts := adoworkitem.HistoryOptions{
+		Ctx:         ctx,
+		WorkItemURL: input.WorkItemURL,
+		Fields:      input.Fields,
+		MaxUpdates:  input.MaxUpdates,
+		OutputJSON:  input.Format == "json",
+	}
+
+	result, err := adoworkitem.History(opts)
+	if err != nil {
+		return nil, adoworkitem.SimplifiedHistory{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, result.History, nil
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// AdoWorkItemQueryInput defines the input schema for the ado_work_item_query tool.
+type AdoWorkItemQueryInput struct {
+	// WIQL query text (required)
+	Query string `json:"query" jsonschema:"WIQL query, e.g. SELECT [System.Id] FROM WorkItems WHERE [System.AssignedTo] = @Me AND [System.State] = 'Active'"`
+	// Project URL (required)
+	ProjectURL string `json:"project_url" jsonschema:"Any Azure DevOps URL inside the project (project home, board, or work item URL)."`
+	// Team for team macros
+	Team string `json:"team,omitempty" jsonschema:"Team name. Required when the query uses team macros such as @CurrentIteration."`
+	// Result limit
+	Top int `json:"top,omitempty" jsonschema:"Maximum number of work items to return. Defaults to 100, max 1000."`
+	// Return full work items
+	Expand bool `json:"expand,omitempty" jsonschema:"Set to true to return full work item details (description, children, attachments) instead of a compact table."`
+	// Output format: toon (default) or json
+	Format string `json:"format,omitempty" jsonschema:"Defaults to a more efficient output format. Set to 'json' to get raw JSON output."`
+}
+
+// AdoWorkItemQueryOutput is the structured content of an ado_work_item_query result:
+// compact rows, or full work items when expand is set.
+type AdoWorkItemQueryOutput struct {
+	Rows      []adoworkitem.WorkItemRow        `json:"rows,omitempty"`
+	WorkItems []adoworkitem.SimplifiedWorkItem `json:"workItems,omitempty"`
+}
+
+// registerAdoWorkItemQueryTool registers the ado_work_item_query tool with the server.
+func registerAdoWorkItemQueryTool(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "ado_work_item_query",
+		Description:  "Run a WIQL query against Azure DevOps work items. Returns a compact table of id, type, state, assignee and title, or full work item details when expand is true. Supports flat queries and link queries (e.g. children of an epic).",
+		OutputSchema: outputSchema[AdoWorkItemQueryOutput](),
+	}, handleAdoWorkItemQuery)
+}
+
+// handleAdoWorkItemQuery handles the ado_work_item_query tool invocation.
+func handleAdoWorkItemQuery(ctx context.Context, req *mcp.CallToolRequest, input AdoWorkItemQueryInput) (*mcp.CallToolResult, AdoWorkItemQueryOutput, error) {
+	if input.Query == "" || input.ProjectURL == "" {
+		return nil, AdoWorkItemQueryOutput{}, errors.New("error: query and project_url are required")
+	}
+
+	opts := adoworkitem.QueryOptions{
+		Ctx:        ctx,
+		ProjectURL: input.ProjectURL,
+		Team:       input.Team,
+		Query:      input.Query,
+		Top:        input.Top,
+		Expand:     input.Expand,
+		OutputJSON: input.Format == "json",
+	}
+
+	result, err := adoworkitem.Query(opts)
+	if err != nil {
+		return nil, AdoWorkItemQueryOutput{}, fmt.Errorf("error: %w", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: result.Output},
+		},
+	}, AdoWorkItemQueryOutput{Rows: result.Rows, WorkItems: result.WorkItems}, nil
+}
@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// shutdownTimeout bounds how long ServeHTTP waits for in-flight requests on shutdown.
+const shutdownTimeout = 10 * time.Second
+
+// HTTPOptions configures ServeHTTP.
+type HTTPOptions struct {
+	// Addr is the address to listen on, e.g. ":8080" or "127.0.0.1:8080".
+	Addr string
+	// Token, if set, is required as a bearer token on every request.
+	Token string
+	// Logf reports the listening address and warnings. Nil disables logging.
+	Logf func(format string, args ...any)
+}
+
+// ServeHTTP serves the MCP server over the streamable HTTP transport at "/" until ctx is
+// canceled, then stops accepting connections and waits (up to shutdownTimeout) for open
+// requests to finish. All sessions share one server, so credential caches stay warm
+// across clients. Requests from web pages are rejected (see rejectBrowserRequests).
+func ServeHTTP(ctx context.Context, server *mcp.Server, opts HTTPOptions) error {
+	logf := opts.Logf
+	if logf == nil {
+		logf = func(string, ...any) {}
+	}
+
+	ln, err := net.Listen("tcp", opts.Addr)
+	if err != nil {
+		return err
+	}
+	if opts.Token == "" && !isLoopback(ln.Addr()) {
+		logf("warning: serving on %s without a bearer token; anyone who can reach it can use your Azure DevOps credentials", ln.Addr())
+	}
+	logf("toolbox-mcp listening on http://%s", ln.Addr())
+
+	srv := &http.Server{
+		Handler:           httpHandler(server, opts.Token),
+		ReadHeaderTimeout: 10 * time.Second,
+		// Requests inherit ctx, so canceling it ends open event streams and in-flight
+		// calls and shutdown does not wait out the timeout on idle clients.
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		_ = srv.Close()
+	}
+	if err := <-errc; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// httpHandler returns the streamable HTTP handler for server, requiring token as a bearer
+// token if it is set.
+func httpHandler(server *mcp.Server, token string) http.Handler {
+	var handler http.Handler = mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return server
+	}, nil)
+	if token != "" {
+		handler = auth.RequireBearerToken(tokenVerifier(token), nil)(handler)
+	}
+	return rejectBrowserRequests(handler, token == "")
+}
+
+// tokenVerifier accepts only the configured bearer token.
+func tokenVerifier(token string) auth.TokenVerifier {
+	return func(_ context.Context, got string, _ *http.Request) (*auth.TokenInfo, error) {
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return nil, fmt.Errorf("%w: wrong bearer token", auth.ErrInvalidToken)
+		}
+		// The static token does not expire, but the middleware requires an expiration.
+		return &auth.TokenInfo{Expiration: time.Now().Add(time.Hour)}, nil
+	}
+}
+
+// rejectBrowserRequests guards against web pages calling the server, as the MCP transport
+// spec requires: requests with an Origin other than a loopback one get 403 Forbidden.
+// Without a bearer token, checkHost also requires the Host header to be "localhost" or an
+// IP address, which defeats DNS rebinding (a page on an attacker's domain whose name
+// resolves to the server's address sends that domain as Host).
+func rejectBrowserRequests(next http.Handler, checkHost bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" {
+			u, err := url.Parse(origin)
+			if err != nil || !isLoopbackHost(u.Host) {
+				http.Error(w, "forbidden origin", http.StatusForbidden)
+				return
+			}
+		}
+		if checkHost && !isLocalOrIPHost(r.Host) {
+			http.Error(w, "forbidden host", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether a host (with optional port) is localhost or a loopback IP.
+func isLoopbackHost(hostport string) bool {
+	host := hostName(hostport)
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// isLocalOrIPHost reports whether a host (with optional port) is localhost or an IP address.
+func isLocalOrIPHost(hostport string) bool {
+	host := hostName(hostport)
+	return strings.EqualFold(host, "localhost") || net.ParseIP(host) != nil
+}
+
+// hostName strips the port and IPv6 brackets from a host.
+func hostName(hostport string) string {
+	if host, _, err := net.SplitHostPort(hostport); err == nil {
+		return host
+	}
+	return strings.Trim(hostport, "[]")
+}
+
+// isLoopback reports whether a listener address only accepts local connections.
+func isLoopback(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && tcp.IP.IsLoopback()
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const initializeRequest = `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`
+
+func TestHTTPHandler(t *testing.T) {
+	t.Parallel()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+
+	tests := []struct {
+		name   string
+		token  string
+		host   string
+		header map[string]string
+		want   int
+	}{
+		{name: "no token on loopback", host: "127.0.0.1:8080", want: http.StatusOK},
+		{name: "no token on localhost", host: "localhost:8080", want: http.StatusOK},
+		{name: "no token by IP", host: "10.0.0.5:8080", want: http.StatusOK},
+		{name: "no token rebound host", host: "attacker.example:8080", want: http.StatusForbidden},
+		{name: "loopback origin", host: "127.0.0.1:8080", header: map[string]string{"Origin": "http://localhost:3000"}, want: http.StatusOK},
+		{name: "foreign origin", host: "127.0.0.1:8080", header: map[string]string{"Origin": "https://attacker.example"}, want: http.StatusForbidden},
+		{name: "null origin", host: "127.0.0.1:8080", header: map[string]string{"Origin": "null"}, want: http.StatusForbidden},
+		{name: "missing bearer", token: "secret", host: "box.example:8080", want: http.StatusUnauthorized},
+		{name: "wrong bearer", token: "secret", host: "box.example:8080", header: map[string]string{"Authorization": "Bearer nope"}, want: http.StatusUnauthorized},
+		{name: "bearer on any host", token: "secret", host: "box.example:8080", header: map[string]string{"Authorization": "Bearer secret"}, want: http.StatusOK},
+		{name: "bearer with foreign origin", token: "secret", host: "box.example:8080", header: map[string]string{"Authorization": "Bearer secret", "Origin": "https://attacker.example"}, want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(initializeRequest))
+			req.Host = tt.host
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/json, text/event-stream")
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			httpHandler(server, tt.token).ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
+
+func TestTokenVerifier(t *testing.T) {
+	t.Parallel()
+
+	verify := tokenVerifier("secret")
+	if _, err := verify(context.Background(), "secret", nil); err != nil {
+		t.Fatalf("verify(secret) = %v", err)
+	}
+	for _, got := range []string{"", "secre", "secret2", "SECRET"} {
+		if _, err := verify(context.Background(), got, nil); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Fatalf("verify(%q) = %v, want ErrInvalidToken", got, err)
+		}
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		addr net.Addr
+		want bool
+	}{
+		{&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}, true},
+		{&net.TCPAddr{IP: net.IPv6loopback, Port: 8080}, true},
+		{&net.TCPAddr{IP: net.IPv4zero, Port: 8080}, false},
+		{&net.TCPAddr{IP: net.IPv4(192, 168, 1, 2), Port: 8080}, false},
+		{&net.UnixAddr{Name: "/tmp/mcp.sock", Net: "unix"}, false},
+	}
+	for _, tt := range tests {
+		if got := isLoopback(tt.addr); got != tt.want {
+			t.Errorf("isLoopback(%v) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"localhost", true},
+		{"LOCALHOST:3000", true},
+		{"127.0.0.1:8080", true},
+		{"[::1]:8080", true},
+		{"::1", true},
+		{"10.0.0.5:8080", false},
+		{"localhost.attacker.example", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := isLoopbackHost(tt.host); got != tt.want {
+			t.Errorf("isLoopbackHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
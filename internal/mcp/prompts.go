@@ -0,0 +1,210 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/krubenok/toolbox/internal/config"
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// promptsDir is the directory in ~/.toolbox holding prompt template overrides, one
+// <prompt name>.md file per prompt.
+const promptsDir = "prompts"
+
+// feedbackContextLines is how much code around each commented line address_pr_feedback
+// embeds.
+const feedbackContextLines = 3
+
+// PRFeedbackData is the data available to the address_pr_feedback template.
+type PRFeedbackData struct {
+	PRURL   string
+	PR      string // PR details in TOON format
+	Threads string // Active comment threads with code context in TOON format
+	Summary string // Thread counts, e.g. how many threads the status filter left out
+}
+
+// WorkItemSummaryData is the data available to the summarize_work_item template.
+type WorkItemSummaryData struct {
+	URL      string
+	WorkItem string // Work item with all sections in TOON format
+}
+
+const defaultAddressPRFeedbackPrompt = `You are addressing reviewer feedback on the Azure DevOps pull request {{.PRURL}}.
+
+## Pull request
+
+{{.PR}}
+
+## Active comment threads
+{{if .Summary}}
+{{.Summary}}
+{{end}}
+{{.Threads}}
+
+Work through the active threads one at a time:
+
+1. Read the comment and the code it refers to (filePath, lineStart and lineEnd, and codeContext where present).
+2. Decide whether the feedback calls for a code change, an answer, or a reasoned disagreement.
+3. Make the code changes in the working tree. Keep each change focused on its thread.
+4. Draft a short reply for the thread saying what you changed, or why you did not.
+
+When you are done, list each thread id with what you did and your drafted reply. Only post replies with ado_pr_reply or resolve threads with ado_pr_thread_status when asked to.
+`
+
+const defaultSummarizeWorkItemPrompt = `Summarize the Azure DevOps work item {{.URL}} for a developer about to work on it.
+
+{{.WorkItem}}
+
+Cover:
+
+- What is being asked and why, from the description and discussion
+- The current state, assignee, and any open questions or decisions in the discussion
+- Related work: parent, children, linked pull requests, commits and builds
+- Acceptance criteria or a definition of done, if stated
+
+Keep it brief, and quote the work item where exact wording matters.
+`
+
+// registerPrompts registers the review workflow prompts with the server.
+func registerPrompts(server *mcp.Server) {
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "address_pr_feedback",
+		Title:       "Address PR feedback",
+		Description: "Work through the active comment threads on an Azure DevOps pull request. Embeds the PR details and active threads with code context.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "pr_url", Description: "Azure DevOps PR URL", Required: true},
+		},
+	}, handleAddressPRFeedbackPrompt)
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "summarize_work_item",
+		Title:       "Summarize work item",
+		Description: "Summarize an Azure DevOps work item, its discussion and related work. Embeds the full work item.",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "url", Description: "Azure DevOps work item URL", Required: true},
+		},
+	}, handleSummarizeWorkItemPrompt)
+}
+
+// handleAddressPRFeedbackPrompt fetches the PR and its active threads into the prompt.
+func handleAddressPRFeedbackPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	prURL := req.Params.Arguments["pr_url"]
+	if prURL == "" {
+		return nil, errors.New("pr_url is required")
+	}
+	tmpl, err := promptTemplate("address_pr_feedback", defaultAddressPRFeedbackPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := adoprcomments.GetPR(adoprcomments.PROptions{Ctx: ctx, PRURL: prURL})
+	if err != nil {
+		return nil, err
+	}
+	threads, err := adoprcomments.Run(adoprcomments.Options{
+		Ctx:                ctx,
+		PRURL:              prURL,
+		Statuses:           []string{"active"},
+		IncludeCodeContext: true,
+		ContextLines:       feedbackContextLines,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPrompt(tmpl, "Address feedback on "+prURL, PRFeedbackData{
+		PRURL:   prURL,
+		PR:      pr.Output,
+		Threads: threads.Output,
+		Summary: threads.Summary,
+	})
+}
+
+// handleSummarizeWorkItemPrompt fetches the work item into the prompt.
+func handleSummarizeWorkItemPrompt(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	url := req.Params.Arguments["url"]
+	if url == "" {
+		return nil, errors.New("url is required")
+	}
+	tmpl, err := promptTemplate("summarize_work_item", defaultSummarizeWorkItemPrompt)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := adoworkitem.Run(adoworkitem.Options{
+		Ctx:         ctx,
+		WorkItemURL: url,
+
+		IncludeDescription: true,
+		IncludeDiscussion:  true,
+		IncludeChildren:    true,
+		IncludeLinks:       true,
+		IncludeAttachments: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderPrompt(tmpl, "Summarize "+url, WorkItemSummaryData{
+		URL:      url,
+		WorkItem: result.Output,
+	})
+}
+
+// promptTemplate loads and parses the template for a prompt (see loadPromptTemplate).
+func promptTemplate(name, builtin string) (*template.Template, error) {
+	text, err := loadPromptTemplate(name, builtin)
+	if err != nil {
+		return nil, err
+	}
+	return parsePromptTemplate(name, text)
+}
+
+// parsePromptTemplate parses the template text for a prompt.
+func parsePromptTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("prompt %s: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// renderPrompt executes a prompt template with data as a single user message.
+func renderPrompt(tmpl *template.Template, description string, data any) (*mcp.GetPromptResult, error) {
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("prompt %s: %w", tmpl.Name(), err)
+	}
+
+	return &mcp.GetPromptResult{
+		Description: description,
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: b.String()}},
+		},
+	}, nil
+}
+
+// loadPromptTemplate returns the template text for a prompt: ~/.toolbox/prompts/<name>.md,
+// or the built-in default. A repository's .toolbox directory is ignored: the prompt tells
+// the agent what it may do, so a cloned repository must not be able to replace it.
+func loadPromptTemplate(name, builtin string) (string, error) {
+	path, err := config.Path(filepath.Join(promptsDir, name+".md"))
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return builtin, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
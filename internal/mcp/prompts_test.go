@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// writePrompt writes a prompt template override into dir/.toolbox/prompts.
+func writePrompt(t *testing.T, dir, name, text string) {
+	t.Helper()
+
+	promptDir := filepath.Join(dir, ".toolbox", promptsDir)
+	if err := os.MkdirAll(promptDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(promptDir, name+".md"), []byte(text), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadPromptTemplate(t *testing.T) {
+	tests := []struct {
+		name string
+		user string // ~/.toolbox/prompts override, if any
+		repo string // repository .toolbox/prompts override, if any
+		want string
+	}{
+		{name: "built-in", want: "builtin"},
+		{name: "user override", user: "user", want: "user"},
+		{name: "repository ignored", repo: "repo", want: "builtin"},
+		{name: "user wins over repository", user: "user", repo: "repo", want: "user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("USERPROFILE", home)
+			repo := t.TempDir()
+			t.Chdir(repo)
+
+			if tt.user != "" {
+				writePrompt(t, home, "test_prompt", tt.user)
+			}
+			if tt.repo != "" {
+				writePrompt(t, repo, "test_prompt", tt.repo)
+			}
+
+			got, err := loadPromptTemplate("test_prompt", "builtin")
+			if err != nil {
+				t.Fatalf("loadPromptTemplate: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("template = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromptTemplateRejectsBadOverride(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Chdir(home)
+	writePrompt(t, home, "address_pr_feedback", "{{.PRURL")
+
+	_, err := promptTemplate("address_pr_feedback", defaultAddressPRFeedbackPrompt)
+	if err == nil || !strings.Contains(err.Error(), "prompt address_pr_feedback") {
+		t.Fatalf("err = %v, want template parse error", err)
+	}
+}
+
+func TestRenderPrompt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builtin string
+		data    any
+		want    []string
+		wantOut []string
+	}{
+		{
+			name:    "address_pr_feedback",
+			builtin: defaultAddressPRFeedbackPrompt,
+			data:    PRFeedbackData{PRURL: "https://dev.azure.com/org/p/_git/r/pullrequest/1", PR: "title: Fix", Threads: "[1]: thread", Summary: "2 closed threads not shown"},
+			want:    []string{"pullrequest/1", "title: Fix", "[1]: thread", "2 closed threads not shown", "ado_pr_reply"},
+		},
+		{
+			name:    "address_pr_feedback without summary",
+			builtin: defaultAddressPRFeedbackPrompt,
+			data:    PRFeedbackData{PRURL: "u", PR: "pr", Threads: "threads"},
+			wantOut: []string{"<no value>"},
+		},
+		{
+			name:    "summarize_work_item",
+			builtin: defaultSummarizeWorkItemPrompt,
+			data:    WorkItemSummaryData{URL: "https://dev.azure.com/org/p/_workitems/edit/5", WorkItem: "id: 5"},
+			want:    []string{"_workitems/edit/5", "id: 5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tmpl, err := parsePromptTemplate(tt.name, tt.builtin)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			got, err := renderPrompt(tmpl, "description", tt.data)
+			if err != nil {
+				t.Fatalf("renderPrompt: %v", err)
+			}
+			if got.Description != "description" || len(got.Messages) != 1 || got.Messages[0].Role != "user" {
+				t.Fatalf("result = %+v", got)
+			}
+			text := got.Messages[0].Content.(*mcp.TextContent).Text
+			for _, s := range tt.want {
+				if !strings.Contains(text, s) {
+					t.Errorf("prompt missing %q:\n%s", s, text)
+				}
+			}
+			for _, s := range tt.wantOut {
+				if strings.Contains(text, s) {
+					t.Errorf("prompt contains %q:\n%s", s, text)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// resourcePollInterval is how often subscribed resources are re-read to detect changes.
+const resourcePollInterval = time.Minute
+
+// registerAdoResources registers the ado:// resource templates with the server.
+func registerAdoResources(server *mcp.Server) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "ado_pr_threads",
+		Title:       "Azure DevOps pull request threads",
+		URITemplate: "ado://{org}/{project}/pr/{repo}/{id}/threads",
+		Description: "Comment threads of an Azure DevOps pull request, filtered and formatted as by the ado_pr_comments tool.",
+		MIMEType:    "text/plain",
+	}, handleAdoResource)
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "ado_work_item",
+		Title:       "Azure DevOps work item",
+		URITemplate: "ado://{org}/{project}/workitem/{id}",
+		Description: "An Azure DevOps work item with its description, discussion and links, formatted as by the ado_work_item tool.",
+		MIMEType:    "text/plain",
+	}, handleAdoResource)
+}
+
+// handleAdoResource reads an ado:// resource.
+func handleAdoResource(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	text, err := readAdoResource(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	return &mcp.ReadResourceResult{
+		Contents: []*mcp.ResourceContents{
+			{URI: uri, MIMEType: "text/plain", Text: text},
+		},
+	}, nil
+}
+
+// adoResource is an Azure DevOps entity addressed by an ado:// URI.
+type adoResource struct {
+	prURL       string // set for pull request threads
+	workItemURL string // set for work items
+}
+
+// parseAdoResourceURI maps an ado:// URI to the web URL of the entity it addresses.
+// Only Azure DevOps Services (dev.azure.com) organizations can be addressed.
+func parseAdoResourceURI(uri string) (*adoResource, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "ado" || u.Host == "" {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	org := u.Host
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	switch {
+	case len(parts) == 5 && parts[1] == "pr" && parts[4] == "threads":
+		id, err := strconv.Atoi(parts[3])
+		if err != nil || id <= 0 {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		return &adoResource{prURL: fmt.Sprintf("%s/%s/%s/_git/%s/pullrequest/%d",
+			ado.DefaultBaseURL, url.PathEscape(org), url.PathEscape(parts[0]), url.PathEscape(parts[2]), id)}, nil
+	case len(parts) == 3 && parts[1] == "workitem":
+		id, err := strconv.Atoi(parts[2])
+		if err != nil || id <= 0 {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		return &adoResource{workItemURL: fmt.Sprintf("%s/%s/%s/_workitems/edit/%d",
+			ado.DefaultBaseURL, url.PathEscape(org), url.PathEscape(parts[0]), id)}, nil
+	}
+	return nil, mcp.ResourceNotFoundError(uri)
+}
+
+// readAdoResource fetches an ado:// resource as TOON text.
+func readAdoResource(ctx context.Context, uri string) (string, error) {
+	res, err := parseAdoResourceURI(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if res.prURL != "" {
+		result, err := adoprcomments.Run(adoprcomments.Options{Ctx: ctx, PRURL: res.prURL})
+		if err != nil {
+			return "", err
+		}
+		if result.Summary == "" {
+			return result.Output, nil
+		}
+		return result.Summary + "\n" + result.Output, nil
+	}
+
+	result, err := adoworkitem.Run(adoworkitem.Options{
+		Ctx:         ctx,
+		WorkItemURL: res.workItemURL,
+
+		IncludeDescription: true,
+		IncludeDiscussion:  true,
+		IncludeChildren:    true,
+		IncludeLinks:       true,
+		IncludeAttachments: true,
+	})
+	if err != nil {
+		return "", err
+	}
+	return result.Output, nil
+}
+
+// resourceWatcher polls subscribed resources and notifies subscribers when they change.
+type resourceWatcher struct {
+	server *mcp.Server
+	// ctx is the server's lifetime; polling stops when it is done.
+	ctx context.Context
+	// read fetches a resource's content (readAdoResource, replaced in tests).
+	read func(ctx context.Context, uri string) (string, error)
+	// interval is how often subscribed resources are polled (resourcePollInterval).
+	interval time.Duration
+
+	mu      sync.Mutex
+	watches map[string]*resourceWatch
+}
+
+// resourceWatch tracks the sessions subscribed to one resource and its polling goroutine.
+type resourceWatch struct {
+	sessions map[*mcp.ServerSession]bool
+	cancel   context.CancelFunc
+}
+
+func newResourceWatcher(ctx context.Context) *resourceWatcher {
+	return &resourceWatcher{
+		ctx:      ctx,
+		read:     readAdoResource,
+		interval: resourcePollInterval,
+		watches:  make(map[string]*resourceWatch),
+	}
+}
+
+// subscribe starts polling a resource on its first subscriber.
+func (w *resourceWatcher) subscribe(_ context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	if _, err := parseAdoResourceURI(uri); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	watch, ok := w.watches[uri]
+	if !ok {
+		ctx, cancel := context.WithCancel(w.ctx)
+		watch = &resourceWatch{sessions: make(map[*mcp.ServerSession]bool), cancel: cancel}
+		w.watches[uri] = watch
+		go w.poll(ctx, uri)
+	}
+	watch.sessions[req.Session] = true
+	return nil
+}
+
+// unsubscribe stops polling a resource once its last subscriber is gone.
+func (w *resourceWatcher) unsubscribe(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	uri := req.Params.URI
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if watch, ok := w.watches[uri]; ok {
+		delete(watch.sessions, req.Session)
+		if len(watch.sessions) == 0 {
+			watch.cancel()
+			delete(w.watches, uri)
+		}
+	}
+	return nil
+}
+
+// poll re-reads a resource every interval and sends a resources/updated notification
+// when its content changes. Read errors are retried on the next tick; until a read
+// succeeds there is nothing to compare against, so no notification is sent.
+func (w *resourceWatcher) poll(ctx context.Context, uri string) {
+	last, err := w.read(ctx, uri)
+	known := err == nil
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if !w.pruneSessions(uri) {
+			return
+		}
+		text, err := w.read(ctx, uri)
+		if err != nil {
+			continue
+		}
+		changed := known && text != last
+		last, known = text, true
+		if !changed {
+			continue
+		}
+		_ = w.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+	}
+}
+
+// pruneSessions drops subscribers whose sessions have ended without unsubscribing and
+// stops watching the resource if none is left. Reports whether it is still watched.
+func (w *resourceWatcher) pruneSessions(uri string) bool {
+	live := make(map[*mcp.ServerSession]bool)
+	for ss := range w.server.Sessions() {
+		live[ss] = true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	watch, ok := w.watches[uri]
+	if !ok {
+		return false
+	}
+	for ss := range watch.sessions {
+		if !live[ss] {
+			delete(watch.sessions, ss)
+		}
+	}
+	if len(watch.sessions) == 0 {
+		watch.cancel()
+		delete(w.watches, uri)
+		return false
+	}
+	return true
+}
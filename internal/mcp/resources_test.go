@@ -0,0 +1,215 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestParseAdoResourceURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		uri      string
+		want     adoResource
+		notFound bool
+	}{
+		{
+			uri:  "ado://contoso/Project/pr/repo/42/threads",
+			want: adoResource{prURL: "https://dev.azure.com/contoso/Project/_git/repo/pullrequest/42"},
+		},
+		{
+			uri:  "ado://contoso/My%20Project/pr/my%20repo/7/threads",
+			want: adoResource{prURL: "https://dev.azure.com/contoso/My%20Project/_git/my%20repo/pullrequest/7"},
+		},
+		{
+			uri:  "ado://contoso/Project/workitem/1234",
+			want: adoResource{workItemURL: "https://dev.azure.com/contoso/Project/_workitems/edit/1234"},
+		},
+		{uri: "ado://contoso/Project/pr/repo/42", notFound: true},
+		{uri: "ado://contoso/Project/pr/repo/abc/threads", notFound: true},
+		{uri: "ado://contoso/Project/pr/repo/0/threads", notFound: true},
+		{uri: "ado://contoso/Project/workitem/-1", notFound: true},
+		{uri: "ado://contoso/Project/workitem/1/extra", notFound: true},
+		{uri: "ado:///Project/workitem/1", notFound: true},
+		{uri: "https://dev.azure.com/contoso/Project/_workitems/edit/1", notFound: true},
+		{uri: "::not a uri", notFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseAdoResourceURI(tt.uri)
+			if tt.notFound {
+				want := mcp.ResourceNotFoundError(tt.uri)
+				if err == nil || err.Error() != want.Error() {
+					t.Fatalf("parseAdoResourceURI = %+v, %v; want %v", got, err, want)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAdoResourceURI: %v", err)
+			}
+			if *got != tt.want {
+				t.Fatalf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceWatcherLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	w := newResourceWatcher(ctx)
+	w.server = server
+	w.read = func(context.Context, string) (string, error) { return "content", nil }
+
+	connect := func() *mcp.ServerSession {
+		t.Helper()
+		serverTransport, clientTransport := mcp.NewInMemoryTransports()
+		ss, err := server.Connect(ctx, serverTransport, nil)
+		if err != nil {
+			t.Fatalf("Connect: %v", err)
+		}
+		client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1"}, nil)
+		cs, err := client.Connect(ctx, clientTransport, nil)
+		if err != nil {
+			t.Fatalf("client Connect: %v", err)
+		}
+		t.Cleanup(func() { _ = cs.Close() })
+		return ss
+	}
+	subscribe := func(ss *mcp.ServerSession, uri string) error {
+		return w.subscribe(ctx, &mcp.SubscribeRequest{Session: ss, Params: &mcp.SubscribeParams{URI: uri}})
+	}
+	unsubscribe := func(ss *mcp.ServerSession, uri string) {
+		t.Helper()
+		if err := w.unsubscribe(ctx, &mcp.UnsubscribeRequest{Session: ss, Params: &mcp.UnsubscribeParams{URI: uri}}); err != nil {
+			t.Fatalf("unsubscribe: %v", err)
+		}
+	}
+	watched := func(uri string) int {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if watch, ok := w.watches[uri]; ok {
+			return len(watch.sessions)
+		}
+		return 0
+	}
+
+	const uri = "ado://contoso/Project/workitem/1"
+	first, second := connect(), connect()
+
+	if err := subscribe(first, "ado://contoso/Project/unknown/1"); err == nil {
+		t.Fatalf("subscribe to unknown resource succeeded")
+	}
+	for _, ss := range []*mcp.ServerSession{first, second, second} {
+		if err := subscribe(ss, uri); err != nil {
+			t.Fatalf("subscribe: %v", err)
+		}
+	}
+	if n := watched(uri); n != 2 {
+		t.Fatalf("subscribers = %d, want 2", n)
+	}
+
+	// The resource stays watched while a subscriber is left.
+	unsubscribe(first, uri)
+	if n := watched(uri); n != 1 {
+		t.Fatalf("subscribers after unsubscribe = %d, want 1", n)
+	}
+	if !w.pruneSessions(uri) {
+		t.Fatalf("pruneSessions dropped a live subscriber")
+	}
+
+	// A session that ends without unsubscribing is pruned, which stops the watch.
+	if err := second.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if w.pruneSessions(uri) {
+		t.Fatalf("pruneSessions kept a closed session")
+	}
+	if n := watched(uri); n != 0 {
+		t.Fatalf("subscribers after prune = %d, want 0", n)
+	}
+
+	// Unsubscribing from an unwatched resource is a no-op.
+	unsubscribe(first, uri)
+}
+
+func TestResourceWatcherPoll(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first read fails, then the content is "a" until the fourth read returns "b".
+	var reads atomic.Int32
+	w := newResourceWatcher(ctx)
+	w.interval = 5 * time.Millisecond
+	w.read = func(context.Context, string) (string, error) {
+		switch n := reads.Add(1); {
+		case n == 1:
+			return "", errors.New("transient")
+		case n < 4:
+			return "a", nil
+		default:
+			return "b", nil
+		}
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1"}, &mcp.ServerOptions{
+		SubscribeHandler:   w.subscribe,
+		UnsubscribeHandler: w.unsubscribe,
+	})
+	w.server = server
+
+	updated := make(chan int32, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "client", Version: "1"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(context.Context, *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- reads.Load()
+		},
+	})
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	cs, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect: %v", err)
+	}
+	defer cs.Close()
+
+	const uri = "ado://contoso/Project/workitem/1"
+	if err := cs.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Only the change from "a" to "b" is reported, not the recovery from the failed read.
+	select {
+	case n := <-updated:
+		if n < 4 {
+			t.Fatalf("update sent after read %d, want after the content changed", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no resources/updated notification")
+	}
+
+	// Ending the server's lifetime stops polling.
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+	before := reads.Load()
+	time.Sleep(50 * time.Millisecond)
+	if after := reads.Load(); after != before {
+		t.Fatalf("polled %d more times after the server context was canceled", after-before)
+	}
+	select {
+	case n := <-updated:
+		t.Fatalf("unexpected second update after read %d", n)
+	default:
+	}
+}
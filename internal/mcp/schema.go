@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/jsonschema-go/jsonschema"
+
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+// outputSchema infers a tool's output schema from T. Arrays also accept null, because
+// the tools leave empty lists nil (which marshals as null) rather than allocating them.
+func outputSchema[T any]() *jsonschema.Schema {
+	s, err := jsonschema.For[T](&jsonschema.ForOptions{
+		TypeSchemas: map[reflect.Type]*jsonschema.Schema{
+			reflect.TypeFor[adoworkitem.SimplifiedChildLink](): childLinkSchema(),
+		},
+	})
+	if err != nil {
+		// The output types are fixed at compile time, so this is a programming error.
+		panic(fmt.Sprintf("infer output schema for %v: %v", reflect.TypeFor[T](), err))
+	}
+	allowNullArrays(s)
+	return s
+}
+
+// childLinkSchema describes a SimplifiedChildLink. The type contains itself, which schema
+// inference rejects as a cycle, so nested children are described as plain objects.
+func childLinkSchema() *jsonschema.Schema {
+	s, err := jsonschema.For[adoworkitem.SimplifiedChildLink](&jsonschema.ForOptions{
+		TypeSchemas: map[reflect.Type]*jsonschema.Schema{
+			reflect.TypeFor[[]adoworkitem.SimplifiedChildLink](): {
+				Type:  "array",
+				Items: &jsonschema.Schema{Type: "object"},
+			},
+		},
+	})
+	if err != nil {
+		panic(fmt.Sprintf("infer child link schema: %v", err))
+	}
+	return s
+}
+
+// allowNullArrays changes every array schema in s to also accept null.
+func allowNullArrays(s *jsonschema.Schema) {
+	if s == nil {
+		return
+	}
+	if s.Type == "array" {
+		s.Type = ""
+		s.Types = []string{"null", "array"}
+	}
+	for _, p := range s.Properties {
+		allowNullArrays(p)
+	}
+	allowNullArrays(s.Items)
+	allowNullArrays(s.AdditionalProperties)
+}
@@ -0,0 +1,171 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/krubenok/toolbox/internal/config"
+	"github.com/krubenok/toolbox/internal/tools/adoprcomments"
+	"github.com/krubenok/toolbox/internal/tools/adoworkitem"
+)
+
+// schemaCase checks that the output schema for T builds and accepts both the zero value
+// (nil lists marshal as null) and a populated sample.
+func schemaCase[T any](sample T) func(t *testing.T) {
+	return func(t *testing.T) {
+		t.Parallel()
+
+		resolved, err := outputSchema[T]().Resolve(nil)
+		if err != nil {
+			t.Fatalf("resolve schema: %v", err)
+		}
+		var zero T
+		for name, v := range map[string]T{"zero": zero, "sample": sample} {
+			data, err := json.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var instance any
+			if err := json.Unmarshal(data, &instance); err != nil {
+				t.Fatal(err)
+			}
+			if err := resolved.Validate(instance); err != nil {
+				t.Errorf("%s value rejected: %v\n%s", name, err, data)
+			}
+		}
+	}
+}
+
+func TestOutputSchemas(t *testing.T) {
+	t.Parallel()
+
+	line := 12
+	thread := adoprcomments.SimplifiedThread{
+		ID:        7,
+		FilePath:  "/src/main.go",
+		LineStart: &line,
+		Status:    "active",
+		Comments:  []adoprcomments.SimplifiedComment{{ID: 1, Author: "Jane", Content: "Please fix"}},
+	}
+	workItem := adoworkitem.SimplifiedWorkItem{
+		ID:    5,
+		Title: "Crash on save",
+		Fields: map[string]any{
+			"Microsoft.VSTS.Common.Priority": 2,
+		},
+		Discussion: []adoworkitem.SimplifiedComment{{ID: 3, Author: "Jane", Text: "Repro attached"}},
+		Children: []adoworkitem.SimplifiedChildLink{
+			{ID: 6, Title: "Parser", Children: []adoworkitem.SimplifiedChildLink{{ID: 8, Title: "Lexer"}}},
+		},
+		Links: adoworkitem.SimplifiedLinks{Parent: []adoworkitem.SimplifiedLink{{ID: "1", URL: "https://dev.azure.com/org/p/_workitems/edit/1"}}},
+	}
+
+	t.Run("ado_pr", schemaCase(adoprcomments.SimplifiedPR{ID: 42, Title: "Fix", Reviewers: []adoprcomments.SimplifiedReviewer{{Name: "Jane", Vote: "approved"}}}))
+	t.Run("ado_pr_comments", schemaCase(AdoPRCommentsOutput{Threads: []adoprcomments.SimplifiedThread{thread}}))
+	t.Run("ado_pr_reply", schemaCase(adoprcomments.ReplyResult{ThreadID: 7, Comment: adoprcomments.SimplifiedComment{ID: 2, Content: "Done."}}))
+	t.Run("ado_pr_thread_status", schemaCase(thread))
+	t.Run("ado_work_item", schemaCase(workItem))
+	t.Run("ado_work_item_comment", schemaCase(adoworkitem.AddCommentResult{WorkItemID: 5, Comment: adoworkitem.SimplifiedComment{ID: 4, Text: "Fixed"}}))
+	t.Run("ado_work_item_edit", schemaCase(AdoWorkItemEditOutput{
+		WorkItem: &workItem,
+		Patch:    []adoworkitem.PatchOperation{{Op: "add", Path: "/fields/System.Title", Value: "Crash on save"}},
+	}))
+	t.Run("ado_work_item_history", schemaCase(adoworkitem.SimplifiedHistory{
+		ID:      5,
+		Updates: []adoworkitem.SimplifiedUpdate{{Rev: 2, Changes: []adoworkitem.FieldChange{{Field: "System.State", From: "New", To: "Active"}}}},
+	}))
+	t.Run("ado_work_item_query", schemaCase(AdoWorkItemQueryOutput{
+		Rows:      []adoworkitem.WorkItemRow{{ID: 5, Title: "Crash on save"}},
+		WorkItems: []adoworkitem.SimplifiedWorkItem{workItem},
+	}))
+}
+
+func TestAllowNullArrays(t *testing.T) {
+	t.Parallel()
+
+	s := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			"name": {Type: "string"},
+			"tags": {Type: "array", Items: &jsonschema.Schema{Type: "string"}},
+			"groups": {
+				Type:                 "object",
+				AdditionalProperties: &jsonschema.Schema{Type: "array", Items: &jsonschema.Schema{Type: "array"}},
+			},
+		},
+	}
+	allowNullArrays(s)
+
+	nullable := []string{"null", "array"}
+	if s.Type != "object" || s.Properties["name"].Type != "string" {
+		t.Fatalf("non-array schemas changed: %+v", s)
+	}
+	for name, got := range map[string]*jsonschema.Schema{
+		"tags":         s.Properties["tags"],
+		"groups":       s.Properties["groups"].AdditionalProperties,
+		"groups items": s.Properties["groups"].AdditionalProperties.Items,
+	} {
+		if got.Type != "" || !slices.Equal(got.Types, nullable) {
+			t.Errorf("%s: type %q, types %v; want types %v", name, got.Type, got.Types, nullable)
+		}
+	}
+}
+
+func TestToolErrorsOmitStructuredContent(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(config.ProfileEnv, "")
+	t.Chdir(home)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := NewServer(ctx).Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server Connect: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1"}, nil)
+	cs, err := client.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client Connect: %v", err)
+	}
+	defer cs.Close()
+
+	// Failing calls are tool errors with only the message. Empty required arguments
+	// pass the input schema, so the handlers reject them before anything runs.
+	tests := []struct {
+		tool string
+		args map[string]any
+		want string
+	}{
+		{tool: "ado_work_item", args: map[string]any{"work_item_url": "https://example.com/not-a-work-item"}, want: "error: "},
+		{tool: "ado_pr", args: map[string]any{"pr_url": ""}, want: "error: pr_url is required"},
+		{tool: "ado_pr_comments", args: map[string]any{"pr_url": ""}, want: "error: pr_url is required"},
+		{tool: "ado_pr_reply", args: map[string]any{"pr_url": "", "thread_id": 1, "message": "Done"}, want: "error: pr_url is required"},
+		{tool: "ado_pr_thread_status", args: map[string]any{"pr_url": "", "thread_id": 1, "status": "fixed"}, want: "error: pr_url is required"},
+		{tool: "ado_work_item_query", args: map[string]any{"query": "", "project_url": ""}, want: "error: query and project_url are required"},
+	}
+	for _, tt := range tests {
+		res, err := cs.CallTool(ctx, &mcp.CallToolParams{Name: tt.tool, Arguments: tt.args})
+		if err != nil {
+			t.Fatalf("%s: CallTool: %v", tt.tool, err)
+		}
+		if !res.IsError || res.StructuredContent != nil || len(res.Content) != 1 {
+			t.Fatalf("%s: result = %+v, want an error without structured content", tt.tool, res)
+		}
+		if text := res.Content[0].(*mcp.TextContent).Text; !strings.HasPrefix(text, tt.want) {
+			t.Errorf("%s: text = %q, want prefix %q", tt.tool, text, tt.want)
+		}
+	}
+
+	// A missing required argument is rejected before the handler runs.
+	_, err = cs.CallTool(ctx, &mcp.CallToolParams{Name: "ado_work_item", Arguments: map[string]any{}})
+	if err == nil || !strings.Contains(err.Error(), "work_item_url") {
+		t.Fatalf("err = %v, want missing work_item_url", err)
+	}
+}
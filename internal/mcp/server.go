@@ -1,18 +1,40 @@
 package mcp
 
 import (
+	"context"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-// NewServer creates and configures the MCP server with all available tools.
-func NewServer() *mcp.Server {
+// NewServer creates and configures the MCP server with all available tools, resources
+// and prompts. Resource subscriptions stop polling when ctx is done.
+func NewServer(ctx context.Context) *mcp.Server {
+	resources := newResourceWatcher(ctx)
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "toolbox",
 		Version: "0.1.0",
-	}, nil)
+	}, &mcp.ServerOptions{
+		SubscribeHandler:   resources.subscribe,
+		UnsubscribeHandler: resources.unsubscribe,
+	})
+	resources.server = server
 
 	// Register tools
+	registerAdoPRTool(server)
 	registerAdoPRCommentsTool(server)
+	registerAdoPRReplyTool(server)
+	registerAdoPRThreadStatusTool(server)
+	registerAdoWorkItemTool(server)
+	registerAdoWorkItemEditTools(server)
+	registerAdoWorkItemCommentTool(server)
+	registerAdoWorkItemHistoryTool(server)
+	registerAdoWorkItemQueryTool(server)
+
+	// Register resources
+	registerAdoResources(server)
+
+	// Register prompts
+	registerPrompts(server)
 
 	return server
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/krubenok/toolbox/internal/ado"
 	"github.com/krubenok/toolbox/internal/auth"
 	toon "github.com/toon-format/toon-go"
 )
@@ -12,12 +13,18 @@ import (
 // Options configures the PR comments fetcher.
 type Options struct {
 	Ctx        context.Context
-	PRURL      string
+	PRURL      string   // Empty = the active PR for the branch checked out in RepoDir
+	RepoDir    string   // Git checkout used to infer the PR when PRURL is empty (empty = working directory)
 	Statuses   []string // Filter to these statuses (empty = use config default, which may also be empty for all)
 	OutputJSON bool     // Output JSON instead of toon
 	Debug      bool
 	NoFilter   bool // Disable content filtering
 	DebugLog   func(string)
+
+	// IncludeCodeContext attaches the anchored lines plus ContextLines lines on each side
+	// to every file-anchored thread.
+	IncludeCodeContext bool
+	ContextLines       int
 }
 
 // Result contains the output from fetching PR comments.
@@ -34,24 +41,27 @@ func Run(opts Options) (*Result, error) {
 		ctx = context.Background()
 	}
 
-	// Parse the PR URL
-	parsed, err := ParsePRURL(opts.PRURL)
-	if err != nil {
-		return nil, err
-	}
-
-	// Get authentication
-	azAuth, err := auth.GetAzureAuth()
-	if err != nil {
-		return nil, err
-	}
-
-	if opts.Debug && opts.DebugLog != nil {
-		opts.DebugLog("Auth: " + azAuth.Scheme)
+	// Parse the PR URL (if empty, the PR is inferred from the git checkout once the client exists)
+	var parsed *ParsedPR
+	var checkout *GitCheckout
+	var baseURL, org string
+	var err error
+	if opts.PRURL != "" {
+		parsed, err = ParsePRURL(opts.PRURL)
+		if err != nil {
+			return nil, err
+		}
+		baseURL, org = parsed.BaseURL, parsed.Organization
+	} else {
+		checkout, err = CurrentCheckout(ctx, opts.RepoDir)
+		if err != nil {
+			return nil, err
+		}
+		baseURL, org = checkout.Remote.BaseURL, checkout.Remote.Organization
 	}
 
 	// Load config
-	cfg, err := LoadConfig()
+	cfg, err := LoadConfig(org)
 	if err != nil {
 		return nil, fmt.Errorf("load config: %w", err)
 	}
@@ -66,7 +76,16 @@ func Run(opts Options) (*Result, error) {
 	}
 
 	// Create client and fetch threads
-	client := NewClient(azAuth, opts.Debug, opts.DebugLog)
+	client, err := newRunClient(baseURL, org, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+	if parsed == nil {
+		parsed, err = client.CurrentPR(ctx, checkout)
+		if err != nil {
+			return nil, err
+		}
+	}
 	threads, err := client.FetchThreads(ctx, parsed)
 	if err != nil {
 		return nil, err
@@ -86,32 +105,18 @@ func Run(opts Options) (*Result, error) {
 	// Simplify threads
 	simplified := SimplifyThreads(filteredThreads, filter)
 
-	// Serialize output
-	var output string
-	if opts.OutputJSON {
-		// JSON output uses structs with omitempty tags
-		jsonBytes, err := json.MarshalIndent(simplified, "", "  ")
-		if err != nil {
+	// Attach code context (opt-in)
+	if opts.IncludeCodeContext {
+		if err := client.AttachCodeContext(ctx, parsed, filteredThreads, simplified, opts.ContextLines); err != nil {
 			return nil, err
 		}
-		output = string(jsonBytes)
-	} else {
-		// TOON output uses maps with configurable field inclusion
-		maps := ThreadsToMaps(simplified, cfg.Output)
-		toonStr, err := toon.MarshalString(maps)
-		if err != nil {
-			// Fall back to JSON if toon fails
-			if opts.Debug && opts.DebugLog != nil {
-				opts.DebugLog("Warning: toon encoding failed, falling back to JSON: " + err.Error())
-			}
-			jsonBytes, err := json.MarshalIndent(simplified, "", "  ")
-			if err != nil {
-				return nil, err
-			}
-			output = string(jsonBytes)
-		} else {
-			output = toonStr
-		}
+	}
+
+	// Serialize output
+	// JSON output uses structs with omitempty tags; TOON output uses maps with configurable field inclusion
+	output, err := marshalOutput(simplified, ThreadsToMaps(simplified, cfg.Output), opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Result{
@@ -120,3 +125,43 @@ func Run(opts Options) (*Result, error) {
 		Output:  output,
 	}, nil
 }
+
+// newRunClient resolves auth and creates a configured client for a single tool invocation
+// against the given base URL and organization (see ParsedPR).
+func newRunClient(baseURL, org string, debug bool, debugLog func(string)) (*Client, error) {
+	azAuth, err := auth.GetAzureAuth(auth.Target{BaseURL: baseURL, Organization: org})
+	if err != nil {
+		return nil, err
+	}
+
+	if debug && debugLog != nil {
+		debugLog("Auth: " + azAuth.Scheme + " (" + azAuth.Source + ")")
+	}
+
+	api, err := ado.NewConfiguredClient(azAuth, baseURL, debug, debugLog)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(api), nil
+}
+
+// marshalOutput serializes jsonValue as indented JSON when outputJSON is set,
+// otherwise serializes toonValue as TOON, falling back to JSON if TOON encoding fails.
+func marshalOutput(jsonValue, toonValue any, outputJSON, debug bool, debugLog func(string)) (string, error) {
+	if !outputJSON {
+		toonStr, err := toon.MarshalString(toonValue)
+		if err == nil {
+			return toonStr, nil
+		}
+		// Fall back to JSON if toon fails
+		if debug && debugLog != nil {
+			debugLog("Warning: toon encoding failed, falling back to JSON: " + err.Error())
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(jsonValue, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
@@ -2,119 +2,93 @@ package adoprcomments
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
-	"strings"
-	"time"
 
-	"github.com/krubenok/toolbox/internal/auth"
+	"github.com/krubenok/toolbox/internal/ado"
 )
 
-const defaultHTTPTimeout = 30 * time.Second
+// apiVersion is the Azure DevOps REST API version used for pull request endpoints.
+const apiVersion = "7.1-preview.1"
 
-// HTTPError represents a non-2xx response from the Azure DevOps API.
-type HTTPError struct {
-	StatusCode int
-	Status     string
-	URL        string
-	Body       string
+// Client handles Azure DevOps pull request API requests.
+type Client struct {
+	api *ado.Client
 }
 
-func (e *HTTPError) Error() string {
-	if e == nil {
-		return "http error"
-	}
-	if e.Body == "" {
-		return fmt.Sprintf("request failed (%s): %s", e.Status, e.URL)
-	}
-	return fmt.Sprintf("request failed (%s): %s: %s", e.Status, e.URL, e.Body)
+// NewClient creates a new PR comments client backed by the shared ADO client.
+func NewClient(api *ado.Client) *Client {
+	return &Client{api: api}
 }
 
-// Client handles Azure DevOps API requests.
-type Client struct {
-	auth       *auth.Auth
-	httpClient *http.Client
-	debug      bool
-	debugLog   func(string)
-}
-
-// NewClient creates a new ADO API client.
-func NewClient(auth *auth.Auth, debug bool, debugLog func(string)) *Client {
-	return &Client{
-		auth:       auth,
-		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
-		debug:      debug,
-		debugLog:   debugLog,
-	}
+// prURL builds the PR details API URL.
+func (c *Client) prURL(pr *ParsedPR) string {
+	return c.api.URL(pr.Organization, pr.Project, "git/pullRequests/"+url.PathEscape(pr.PRID), apiVersion, nil)
 }
 
-// fetchJSON performs a GET request and decodes the JSON response.
-func (c *Client) fetchJSON(ctx context.Context, apiURL string, result any) error {
-	if c.debug && c.debugLog != nil {
-		c.debugLog(fmt.Sprintf("Fetching: %s", apiURL))
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Authorization", c.auth.AuthorizationHeader())
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
-		return &HTTPError{
-			StatusCode: resp.StatusCode,
-			Status:     resp.Status,
-			URL:        apiURL,
-			Body:       strings.TrimSpace(string(bodyBytes)),
-		}
-	}
+// workItemsURL builds the API URL for the work items linked to a PR.
+func (c *Client) workItemsURL(pr *ParsedPR, repo string) string {
+	path := fmt.Sprintf(
+		"git/repositories/%s/pullRequests/%s/workitems",
+		url.PathEscape(repo),
+		url.PathEscape(pr.PRID),
+	)
+	return c.api.URL(pr.Organization, pr.Project, path, apiVersion, nil)
+}
 
-	return json.NewDecoder(resp.Body).Decode(result)
+// threadsURL builds the PR threads API URL for a repository name or ID.
+func (c *Client) threadsURL(pr *ParsedPR, repo string) string {
+	path := fmt.Sprintf(
+		"git/repositories/%s/pullRequests/%s/threads",
+		url.PathEscape(repo),
+		url.PathEscape(pr.PRID),
+	)
+	return c.api.URL(pr.Organization, pr.Project, path, apiVersion, nil)
 }
 
-// threadsURL builds the PR threads API URL.
-func threadsURL(pr *ParsedPR) string {
-	return fmt.Sprintf(
-		"https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullRequests/%s/threads?api-version=7.1-preview.1",
-		url.PathEscape(pr.Organization),
-		url.PathEscape(pr.Project),
-		url.PathEscape(pr.Repository),
+// iterationURL builds the API URL for a single PR iteration.
+func (c *Client) iterationURL(pr *ParsedPR, repo string, iterationID int) string {
+	path := fmt.Sprintf(
+		"git/repositories/%s/pullRequests/%s/iterations/%d",
+		url.PathEscape(repo),
 		url.PathEscape(pr.PRID),
+		iterationID,
 	)
+	return c.api.URL(pr.Organization, pr.Project, path, apiVersion, nil)
 }
 
-// prURL builds the PR details API URL.
-func prURL(pr *ParsedPR) string {
-	return fmt.Sprintf(
-		"https://dev.azure.com/%s/%s/_apis/git/pullRequests/%s?api-version=7.1-preview.1",
-		url.PathEscape(pr.Organization),
-		url.PathEscape(pr.Project),
+// itemURL builds the Git items API URL for a file at a specific commit.
+func (c *Client) itemURL(pr *ParsedPR, repo, filePath, commitID string) string {
+	query := url.Values{
+		"path":                          {filePath},
+		"versionDescriptor.version":     {commitID},
+		"versionDescriptor.versionType": {"commit"},
+		"includeContent":                {"true"},
+	}
+	return c.api.URL(pr.Organization, pr.Project, "git/repositories/"+url.PathEscape(repo)+"/items", apiVersion, query)
+}
+
+// threadURL builds the API URL for a single PR thread.
+func (c *Client) threadURL(pr *ParsedPR, repo string, threadID int) string {
+	path := fmt.Sprintf(
+		"git/repositories/%s/pullRequests/%s/threads/%d",
+		url.PathEscape(repo),
 		url.PathEscape(pr.PRID),
+		threadID,
 	)
+	return c.api.URL(pr.Organization, pr.Project, path, apiVersion, nil)
 }
 
-// threadsURLByRepoID builds the PR threads API URL using repository ID.
-func threadsURLByRepoID(pr *ParsedPR, repoID string) string {
-	return fmt.Sprintf(
-		"https://dev.azure.com/%s/%s/_apis/git/repositories/%s/pullRequests/%s/threads?api-version=7.1-preview.1",
-		url.PathEscape(pr.Organization),
-		url.PathEscape(pr.Project),
-		url.PathEscape(repoID),
+// threadCommentsURL builds the API URL for the comments of a single PR thread.
+func (c *Client) threadCommentsURL(pr *ParsedPR, repo string, threadID int) string {
+	path := fmt.Sprintf(
+		"git/repositories/%s/pullRequests/%s/threads/%d/comments",
+		url.PathEscape(repo),
 		url.PathEscape(pr.PRID),
+		threadID,
 	)
+	return c.api.URL(pr.Organization, pr.Project, path, apiVersion, nil)
 }
 
 // ThreadsResponse represents the API response for PR threads.
@@ -124,11 +98,23 @@ type ThreadsResponse struct {
 
 // Thread represents a comment thread on a PR.
 type Thread struct {
-	ID            int            `json:"id"`
-	Status        string         `json:"status"`
-	ThreadContext *ThreadContext `json:"threadContext"`
-	Properties    *ThreadProps   `json:"properties"`
-	Comments      []Comment      `json:"comments"`
+	ID                       int                       `json:"id"`
+	Status                   string                    `json:"status"`
+	ThreadContext            *ThreadContext            `json:"threadContext"`
+	PullRequestThreadContext *PullRequestThreadContext `json:"pullRequestThreadContext"`
+	Properties               *ThreadProps              `json:"properties"`
+	Comments                 []Comment                 `json:"comments"`
+}
+
+// PullRequestThreadContext ties a thread to the PR iterations it was created against.
+type PullRequestThreadContext struct {
+	IterationContext *IterationContext `json:"iterationContext"`
+}
+
+// IterationContext identifies the iterations compared when the thread was created.
+type IterationContext struct {
+	FirstComparingIteration  int `json:"firstComparingIteration"`
+	SecondComparingIteration int `json:"secondComparingIteration"`
 }
 
 // ThreadContext contains file location information.
@@ -156,6 +142,7 @@ type PropValue struct {
 // Comment represents a single comment in a thread.
 type Comment struct {
 	ID              int     `json:"id"`
+	ParentCommentID int     `json:"parentCommentId"`
 	Content         string  `json:"content"`
 	CommentType     string  `json:"commentType"`
 	PublishedDate   string  `json:"publishedDate"`
@@ -166,11 +153,63 @@ type Comment struct {
 // Author represents a comment author.
 type Author struct {
 	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
 }
 
 // PRResponse represents the PR details API response.
 type PRResponse struct {
-	Repository *RepoInfo `json:"repository"`
+	PullRequestID         int           `json:"pullRequestId"`
+	Title                 string        `json:"title"`
+	Description           string        `json:"description"`
+	Status                string        `json:"status"`
+	IsDraft               bool          `json:"isDraft"`
+	MergeStatus           string        `json:"mergeStatus"`
+	SourceRefName         string        `json:"sourceRefName"`
+	TargetRefName         string        `json:"targetRefName"`
+	CreationDate          string        `json:"creationDate"`
+	CreatedBy             *Author       `json:"createdBy"`
+	Reviewers             []Reviewer    `json:"reviewers"`
+	WorkItemRefs          []ResourceRef `json:"workItemRefs"` // filled in by FetchPRDetails
+	Repository            *RepoInfo     `json:"repository"`
+	LastMergeSourceCommit *CommitRef    `json:"lastMergeSourceCommit"`
+}
+
+// WorkItemRefsResponse represents the PR work items API response.
+type WorkItemRefsResponse struct {
+	Value []ResourceRef `json:"value"`
+}
+
+// Reviewer represents a PR reviewer and their vote.
+type Reviewer struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	Vote        int    `json:"vote"`
+	IsRequired  bool   `json:"isRequired"`
+	HasDeclined bool   `json:"hasDeclined"`
+	IsContainer bool   `json:"isContainer"`
+}
+
+// ResourceRef is a reference to another ADO resource (e.g. a linked work item).
+type ResourceRef struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+}
+
+// CommitRef identifies a Git commit.
+type CommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// Iteration represents a single PR iteration (push).
+type Iteration struct {
+	ID              int        `json:"id"`
+	SourceRefCommit *CommitRef `json:"sourceRefCommit"`
+}
+
+// GitItem represents a file returned by the Git items API.
+type GitItem struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
 }
 
 // RepoInfo contains repository information.
@@ -178,41 +217,132 @@ type RepoInfo struct {
 	ID string `json:"id"`
 }
 
+// withRepoFallback calls fn with the repository name from the URL. If that fails with a 404
+// (for example, because the URL uses a display name), it looks up the PR to get the
+// repository ID and calls fn again with it.
+func (c *Client) withRepoFallback(ctx context.Context, pr *ParsedPR, fn func(repo string) error) error {
+	err := fn(pr.Repository)
+	if err == nil || !ado.IsStatus(err, http.StatusNotFound) {
+		return err
+	}
+
+	c.api.Debugf("Request 404; attempting to resolve PR repository and retry")
+
+	// Fetch PR details to get repository ID
+	prResp, err := c.FetchPR(ctx, pr)
+	if err != nil {
+		return err
+	}
+
+	if prResp.Repository == nil || prResp.Repository.ID == "" {
+		return fmt.Errorf("PR response missing repository.id")
+	}
+
+	// Retry with repository ID
+	return fn(prResp.Repository.ID)
+}
+
 // FetchThreads retrieves PR comment threads from Azure DevOps.
 // It handles 404 errors by looking up the PR to get the repository ID.
 func (c *Client) FetchThreads(ctx context.Context, pr *ParsedPR) ([]Thread, error) {
 	var threadsResp ThreadsResponse
+	err := c.withRepoFallback(ctx, pr, func(repo string) error {
+		return c.api.GetJSON(ctx, c.threadsURL(pr, repo), &threadsResp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return threadsResp.Value, nil
+}
+
+// NewComment is the request body for posting a comment to a PR thread.
+type NewComment struct {
+	Content         string `json:"content"`
+	ParentCommentID int    `json:"parentCommentId,omitempty"`
+	CommentType     int    `json:"commentType"`
+}
+
+// commentTypeText is the ADO enum value for a regular user comment.
+const commentTypeText = 1
 
-	// Try fetching threads directly
-	err := c.fetchJSON(ctx, threadsURL(pr), &threadsResp)
-	if err == nil {
-		return threadsResp.Value, nil
+// PostComment adds a comment to an existing PR thread and returns the created comment.
+// A parentCommentID of 0 posts the comment without a parent.
+func (c *Client) PostComment(ctx context.Context, pr *ParsedPR, threadID int, content string, parentCommentID int) (*Comment, error) {
+	body := NewComment{
+		Content:         content,
+		ParentCommentID: parentCommentID,
+		CommentType:     commentTypeText,
 	}
 
-	// If not a 404, return the error
-	var httpErr *HTTPError
-	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound {
+	var created Comment
+	err := c.withRepoFallback(ctx, pr, func(repo string) error {
+		return c.api.SendJSON(ctx, http.MethodPost, c.threadCommentsURL(pr, repo, threadID), "", body, &created)
+	})
+	if err != nil {
 		return nil, err
 	}
+	return &created, nil
+}
+
+// threadStatusUpdate is the request body for changing a PR thread's status.
+type threadStatusUpdate struct {
+	Status string `json:"status"`
+}
 
-	if c.debug && c.debugLog != nil {
-		c.debugLog("Thread fetch 404; attempting to resolve PR and retry")
+// UpdateThreadStatus sets the status of an existing PR thread and returns the updated thread.
+func (c *Client) UpdateThreadStatus(ctx context.Context, pr *ParsedPR, threadID int, status string) (*Thread, error) {
+	var updated Thread
+	err := c.withRepoFallback(ctx, pr, func(repo string) error {
+		return c.api.SendJSON(ctx, http.MethodPatch, c.threadURL(pr, repo, threadID), "", threadStatusUpdate{Status: status}, &updated)
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &updated, nil
+}
 
-	// Fetch PR details to get repository ID
+// FetchPR retrieves the PR details.
+func (c *Client) FetchPR(ctx context.Context, pr *ParsedPR) (*PRResponse, error) {
 	var prResp PRResponse
-	if err := c.fetchJSON(ctx, prURL(pr), &prResp); err != nil {
+	if err := c.api.GetJSON(ctx, c.prURL(pr), &prResp); err != nil {
 		return nil, err
 	}
+	return &prResp, nil
+}
 
+// FetchPRDetails retrieves the PR details along with its linked work items, which the
+// project-scoped PR API does not return.
+func (c *Client) FetchPRDetails(ctx context.Context, pr *ParsedPR) (*PRResponse, error) {
+	prResp, err := c.FetchPR(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
 	if prResp.Repository == nil || prResp.Repository.ID == "" {
 		return nil, fmt.Errorf("PR response missing repository.id")
 	}
 
-	// Retry with repository ID
-	if err := c.fetchJSON(ctx, threadsURLByRepoID(pr, prResp.Repository.ID), &threadsResp); err != nil {
+	var refs WorkItemRefsResponse
+	if err := c.api.GetJSON(ctx, c.workItemsURL(pr, prResp.Repository.ID), &refs); err != nil {
+		return nil, fmt.Errorf("fetch linked work items: %w", err)
+	}
+	prResp.WorkItemRefs = refs.Value
+	return prResp, nil
+}
+
+// FetchIteration retrieves a single PR iteration.
+func (c *Client) FetchIteration(ctx context.Context, pr *ParsedPR, repoID string, iterationID int) (*Iteration, error) {
+	var it Iteration
+	if err := c.api.GetJSON(ctx, c.iterationURL(pr, repoID, iterationID), &it); err != nil {
 		return nil, err
 	}
+	return &it, nil
+}
 
-	return threadsResp.Value, nil
+// FetchFileContent retrieves the content of a file at the given commit.
+func (c *Client) FetchFileContent(ctx context.Context, pr *ParsedPR, repoID, filePath, commitID string) (string, error) {
+	var item GitItem
+	if err := c.api.GetJSON(ctx, c.itemURL(pr, repoID, filePath, commitID), &item); err != nil {
+		return "", err
+	}
+	return item.Content, nil
 }
@@ -0,0 +1,109 @@
+package adoprcomments
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func jsonResponse(r *http.Request, status int, payload any) (*http.Response, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(b))),
+		Request:    r,
+	}, nil
+}
+
+func TestClientPostCommentResolvesRepository(t *testing.T) {
+	t.Parallel()
+
+	pr := &ParsedPR{Organization: "org", Project: "project", Repository: "repo name", PRID: "123"}
+
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
+	client := NewClient(api)
+
+	var posted NewComment
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case strings.Contains(r.URL.Path, "/repositories/repo name/"):
+			return jsonResponse(r, http.StatusNotFound, map[string]string{"message": "not found"})
+		case strings.HasSuffix(r.URL.Path, "/_apis/git/pullRequests/123"):
+			return jsonResponse(r, http.StatusOK, PRResponse{Repository: &RepoInfo{ID: "repo-guid"}})
+		case strings.HasSuffix(r.URL.Path, "/repositories/repo-guid/pullRequests/123/threads/42/comments"):
+			if r.Method != http.MethodPost {
+				t.Fatalf("method = %s, want POST", r.Method)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			return jsonResponse(r, http.StatusOK, Comment{
+				ID:              3,
+				ParentCommentID: posted.ParentCommentID,
+				Content:         posted.Content,
+				CommentType:     "text",
+				Author:          &Author{DisplayName: "Agent"},
+			})
+		}
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		return nil, nil
+	}))
+
+	created, err := client.PostComment(context.Background(), pr, 42, "Done.", 1)
+	if err != nil {
+		t.Fatalf("PostComment: %v", err)
+	}
+	if posted.Content != "Done." || posted.ParentCommentID != 1 || posted.CommentType != commentTypeText {
+		t.Fatalf("unexpected request body: %+v", posted)
+	}
+
+	sc := SimplifyComment(*created, nil)
+	if sc.ID != 3 || sc.Author != "Agent" || sc.Content != "Done." {
+		t.Fatalf("unexpected simplified comment: %+v", sc)
+	}
+}
+
+func TestClientFetchPRDetailsIncludesWorkItems(t *testing.T) {
+	t.Parallel()
+
+	pr := &ParsedPR{Organization: "org", Project: "project", Repository: "repo", PRID: "123"}
+
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/org/project/_apis/git/pullRequests/123":
+			return jsonResponse(r, http.StatusOK, PRResponse{PullRequestID: 123, Title: "Fix", Repository: &RepoInfo{ID: "repo-guid"}})
+		case "/org/project/_apis/git/repositories/repo-guid/pullRequests/123/workitems":
+			return jsonResponse(r, http.StatusOK, WorkItemRefsResponse{Value: []ResourceRef{
+				{ID: "42", URL: "https://example.test/org/_apis/wit/workItems/42"},
+				{ID: "7", URL: "https://example.test/org/_apis/wit/workItems/7"},
+			}})
+		}
+		t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		return nil, nil
+	}))
+
+	prResp, err := NewClient(api).FetchPRDetails(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("FetchPRDetails: %v", err)
+	}
+	got := SimplifyPR(*prResp)
+	if got.ID != 123 || len(got.WorkItems) != 2 || got.WorkItems[0] != 7 || got.WorkItems[1] != 42 {
+		t.Fatalf("SimplifyPR = %+v, want work items [7 42]", got)
+	}
+}
@@ -0,0 +1,159 @@
+package adoprcomments
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// maxContextLines caps the number of surrounding lines requested per side.
+const maxContextLines = 100
+
+// codeContextLoader fetches and caches file contents for PR threads.
+type codeContextLoader struct {
+	client  *Client
+	pr      *ParsedPR
+	repoID  string
+	headSHA string              // PR source commit, used when a thread has no iteration context
+	commits map[int]string      // iteration ID -> source commit
+	files   map[string][]string // commit + path -> file lines
+}
+
+// AttachCodeContext fetches the file each thread is anchored to and sets CodeContext
+// to the anchored lines plus n lines on each side. The file is read at the source commit
+// of the iteration the thread was created on, falling back to the PR's latest source commit.
+// raw and simplified must be parallel slices as produced by SimplifyThreads.
+// Threads whose file cannot be fetched are left without context.
+func (c *Client) AttachCodeContext(ctx context.Context, pr *ParsedPR, raw []Thread, simplified []SimplifiedThread, n int) error {
+	if n < 0 {
+		return fmt.Errorf("context lines must be >= 0")
+	}
+	if n > maxContextLines {
+		n = maxContextLines
+	}
+
+	loader := &codeContextLoader{
+		client:  c,
+		pr:      pr,
+		commits: make(map[int]string),
+		files:   make(map[string][]string),
+	}
+
+	for i := range simplified {
+		t := &simplified[i]
+		if t.FilePath == "" || t.LineStart == nil {
+			continue
+		}
+		if loader.repoID == "" {
+			prResp, err := c.FetchPR(ctx, pr)
+			if err != nil {
+				return err
+			}
+			if prResp.Repository == nil || prResp.Repository.ID == "" {
+				return fmt.Errorf("PR response missing repository.id")
+			}
+			loader.repoID = prResp.Repository.ID
+			if prResp.LastMergeSourceCommit != nil {
+				loader.headSHA = prResp.LastMergeSourceCommit.CommitID
+			}
+		}
+
+		lines, err := loader.fileLines(ctx, raw[i], t.FilePath)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.api.Debugf("Skipping code context for %s: %v", t.FilePath, err)
+			continue
+		}
+
+		end := *t.LineStart
+		if t.LineEnd != nil {
+			end = *t.LineEnd
+		}
+		t.CodeContext = buildCodeContext(lines, *t.LineStart, end, n)
+	}
+
+	return nil
+}
+
+// fileLines returns the lines of filePath at the commit the thread was created against.
+func (l *codeContextLoader) fileLines(ctx context.Context, thread Thread, filePath string) ([]string, error) {
+	commit, err := l.commitForThread(ctx, thread)
+	if err != nil {
+		return nil, err
+	}
+	if commit == "" {
+		return nil, fmt.Errorf("no source commit available")
+	}
+
+	key := commit + ":" + filePath
+	if lines, ok := l.files[key]; ok {
+		return lines, nil
+	}
+
+	content, err := l.client.FetchFileContent(ctx, l.pr, l.repoID, filePath, commit)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	l.files[key] = lines
+	return lines, nil
+}
+
+// commitForThread resolves the source commit of the iteration a thread was created on.
+func (l *codeContextLoader) commitForThread(ctx context.Context, thread Thread) (string, error) {
+	ctxInfo := thread.PullRequestThreadContext
+	if ctxInfo == nil || ctxInfo.IterationContext == nil || ctxInfo.IterationContext.SecondComparingIteration <= 0 {
+		return l.headSHA, nil
+	}
+
+	iterationID := ctxInfo.IterationContext.SecondComparingIteration
+	if commit, ok := l.commits[iterationID]; ok {
+		return commit, nil
+	}
+
+	it, err := l.client.FetchIteration(ctx, l.pr, l.repoID, iterationID)
+	if err != nil {
+		return "", err
+	}
+	commit := l.headSHA
+	if it.SourceRefCommit != nil && it.SourceRefCommit.CommitID != "" {
+		commit = it.SourceRefCommit.CommitID
+	}
+	l.commits[iterationID] = commit
+	return commit, nil
+}
+
+// buildCodeContext renders lines [start-n, end+n] (1-based) with line numbers.
+// Anchored lines are marked with ">" so the commented range stands out.
+func buildCodeContext(lines []string, start, end, n int) string {
+	if start <= 0 || len(lines) == 0 {
+		return ""
+	}
+	if end < start {
+		end = start
+	}
+
+	// Drop the empty element produced by a trailing newline.
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	from := max(start-n, 1)
+	to := min(end+n, len(lines))
+	if from > to {
+		return ""
+	}
+
+	width := len(fmt.Sprint(to))
+	var b strings.Builder
+	for i := from; i <= to; i++ {
+		marker := " "
+		if i >= start && i <= end {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "%s%*d | %s\n", marker, width, i, lines[i-1])
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
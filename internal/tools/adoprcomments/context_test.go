@@ -0,0 +1,117 @@
+package adoprcomments
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestBuildCodeContext(t *testing.T) {
+	t.Parallel()
+
+	lines := strings.Split("one\ntwo\nthree\nfour\nfive\n", "\n")
+
+	tests := []struct {
+		name       string
+		start, end int
+		n          int
+		want       string
+	}{
+		{
+			name:  "single line with context",
+			start: 3, end: 3, n: 1,
+			want: " 2 | two\n>3 | three\n 4 | four",
+		},
+		{
+			name:  "range clamps to file bounds",
+			start: 1, end: 2, n: 5,
+			want: ">1 | one\n>2 | two\n 3 | three\n 4 | four\n 5 | five",
+		},
+		{
+			name:  "no surrounding lines",
+			start: 5, end: 5, n: 0,
+			want: ">5 | five",
+		},
+		{
+			name:  "line beyond file",
+			start: 9, end: 9, n: 0,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := buildCodeContext(lines, tt.start, tt.end, tt.n)
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachCodeContextUsesThreadIteration(t *testing.T) {
+	t.Parallel()
+
+	pr := &ParsedPR{Organization: "org", Project: "project", Repository: "repo", PRID: "7"}
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
+	client := NewClient(api)
+
+	var fetchedVersions []string
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/_apis/git/pullRequests/7"):
+			return jsonResponse(r, http.StatusOK, PRResponse{
+				Repository:            &RepoInfo{ID: "repo-guid"},
+				LastMergeSourceCommit: &CommitRef{CommitID: "head"},
+			})
+		case strings.HasSuffix(r.URL.Path, "/pullRequests/7/iterations/2"):
+			return jsonResponse(r, http.StatusOK, Iteration{ID: 2, SourceRefCommit: &CommitRef{CommitID: "iter2"}})
+		case strings.HasSuffix(r.URL.Path, "/repositories/repo-guid/items"):
+			version := r.URL.Query().Get("versionDescriptor.version")
+			fetchedVersions = append(fetchedVersions, version)
+			return jsonResponse(r, http.StatusOK, GitItem{Path: "/main.go", Content: version + "-a\n" + version + "-b\n" + version + "-c\n"})
+		}
+		t.Fatalf("unexpected request: %s", r.URL)
+		return nil, nil
+	}))
+
+	line := 2
+	raw := []Thread{
+		{
+			ID:            1,
+			ThreadContext: &ThreadContext{FilePath: "/main.go", RightFileStart: &FilePosition{Line: line}},
+			PullRequestThreadContext: &PullRequestThreadContext{
+				IterationContext: &IterationContext{FirstComparingIteration: 1, SecondComparingIteration: 2},
+			},
+		},
+		{
+			ID:            2,
+			ThreadContext: &ThreadContext{FilePath: "/main.go", RightFileStart: &FilePosition{Line: line}},
+		},
+		{ID: 3},
+	}
+	simplified := SimplifyThreads(raw, nil)
+
+	if err := client.AttachCodeContext(context.Background(), pr, raw, simplified, 0); err != nil {
+		t.Fatalf("AttachCodeContext: %v", err)
+	}
+
+	if simplified[0].CodeContext != ">2 | iter2-b" {
+		t.Fatalf("thread 1 context = %q", simplified[0].CodeContext)
+	}
+	if simplified[1].CodeContext != ">2 | head-b" {
+		t.Fatalf("thread 2 context = %q", simplified[1].CodeContext)
+	}
+	if simplified[2].CodeContext != "" {
+		t.Fatalf("thread 3 context = %q, want empty", simplified[2].CodeContext)
+	}
+	if len(fetchedVersions) != 2 {
+		t.Fatalf("fetched versions = %v, want one fetch per commit", fetchedVersions)
+	}
+}
@@ -0,0 +1,209 @@
+package adoprcomments
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/krubenok/toolbox/internal/ado"
+)
+
+// GitRemote identifies an Azure DevOps repository from a git remote URL.
+type GitRemote struct {
+	BaseURL      string // API base URL, e.g. https://dev.azure.com
+	Organization string
+	Project      string
+	Repository   string
+}
+
+// ParseGitRemoteURL parses an Azure DevOps git remote URL. Supported formats:
+//
+//	https://[user@]dev.azure.com/{org}/{project}/_git/{repo}
+//	https://{org}.visualstudio.com/[DefaultCollection/]{project}/_git/{repo}
+//	https://{server}/{pathPrefix}/{collection}/{project}/_git/{repo} (hosts configured in ~/.toolbox/ado.json)
+//	git@ssh.dev.azure.com:v3/{org}/{project}/{repo}
+//	ssh://git@ssh.dev.azure.com/v3/{org}/{project}/{repo}
+//	{org}@vs-ssh.visualstudio.com:v3/{org}/{project}/{repo}
+func ParseGitRemoteURL(remote string) (*GitRemote, error) {
+	remote = strings.TrimSpace(remote)
+
+	var host, path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return nil, fmt.Errorf("invalid git remote URL: %s", remote)
+		}
+		if u.Scheme == "http" || u.Scheme == "https" {
+			return parseHTTPRemote(remote)
+		}
+		host, path = u.Hostname(), u.Path
+	} else if at, colon := strings.Index(remote, "@"), strings.Index(remote, ":"); at >= 0 && colon > at {
+		// scp-like syntax: user@host:path
+		host, path = remote[at+1:colon], remote[colon+1:]
+	} else {
+		return nil, fmt.Errorf("invalid git remote URL: %s", remote)
+	}
+
+	host = strings.ToLower(host)
+	if host != "ssh.dev.azure.com" && host != "vs-ssh.visualstudio.com" {
+		return nil, fmt.Errorf("git remote is not an Azure DevOps repository: %s", remote)
+	}
+
+	// Expected: [v3, org, project, repo]
+	parts := ado.SplitPath(strings.TrimSuffix(path, ".git"))
+	if len(parts) != 4 || parts[0] != "v3" {
+		return nil, fmt.Errorf("git remote path does not match expected %s format: %s", host, remote)
+	}
+	return &GitRemote{BaseURL: ado.DefaultBaseURL, Organization: parts[1], Project: parts[2], Repository: parts[3]}, nil
+}
+
+// parseHTTPRemote parses an HTTPS clone URL: {server}/{org}/{project}/_git/{repo}.
+func parseHTTPRemote(remote string) (*GitRemote, error) {
+	server, parts, err := ado.ParseURL(strings.TrimSuffix(remote, ".git"))
+	if err != nil {
+		return nil, fmt.Errorf("git remote is not an Azure DevOps repository: %w", err)
+	}
+
+	// Expected: [project, _git, repo]
+	if len(parts) != 3 || parts[1] != "_git" {
+		return nil, fmt.Errorf("git remote path does not match expected {org}/{project}/_git/{repo} format: %s", remote)
+	}
+	return &GitRemote{
+		BaseURL:      server.BaseURL,
+		Organization: server.Organization,
+		Project:      parts[0],
+		Repository:   parts[2],
+	}, nil
+}
+
+// PRURL returns the web URL of a pull request in the repository.
+func (r *GitRemote) PRURL(prID int) string {
+	return fmt.Sprintf("%s/%s/%s/_git/%s/pullrequest/%d",
+		ado.NormalizeBaseURL(r.BaseURL),
+		url.PathEscape(r.Organization),
+		url.PathEscape(r.Project),
+		url.PathEscape(r.Repository),
+		prID,
+	)
+}
+
+// pullRequestsURL builds the API URL that searches a repository's PRs by source branch.
+func (c *Client) pullRequestsURL(remote *GitRemote, sourceRef, status string) string {
+	query := url.Values{
+		"searchCriteria.sourceRefName": {sourceRef},
+		"searchCriteria.status":        {status},
+	}
+	path := "git/repositories/" + url.PathEscape(remote.Repository) + "/pullrequests"
+	return c.api.URL(remote.Organization, remote.Project, path, apiVersion, query)
+}
+
+// PullRequestsResponse represents the API response for a PR search.
+type PullRequestsResponse struct {
+	Value []PRResponse `json:"value"`
+}
+
+// FindActivePR returns the ID of the single active PR whose source is the given branch.
+func (c *Client) FindActivePR(ctx context.Context, remote *GitRemote, branch string) (int, error) {
+	sourceRef := "refs/heads/" + strings.TrimPrefix(branch, "refs/heads/")
+
+	var resp PullRequestsResponse
+	if err := c.api.GetJSON(ctx, c.pullRequestsURL(remote, sourceRef, "active"), &resp); err != nil {
+		return 0, err
+	}
+
+	switch len(resp.Value) {
+	case 0:
+		return 0, fmt.Errorf("no active pull request found for branch %s in %s/%s/%s",
+			branch, remote.Organization, remote.Project, remote.Repository)
+	case 1:
+		return resp.Value[0].PullRequestID, nil
+	default:
+		ids := make([]string, 0, len(resp.Value))
+		for _, pr := range resp.Value {
+			ids = append(ids, strconv.Itoa(pr.PullRequestID))
+		}
+		return 0, fmt.Errorf("multiple active pull requests found for branch %s (%s); pass the PR URL instead",
+			branch, strings.Join(ids, ", "))
+	}
+}
+
+// GitCheckout is the repository and branch checked out in a local git working tree.
+type GitCheckout struct {
+	Remote *GitRemote
+	Branch string
+}
+
+// CurrentCheckout reads the origin remote and current branch of the git checkout in dir
+// (empty = working directory).
+func CurrentCheckout(ctx context.Context, dir string) (*GitCheckout, error) {
+	remoteURL, err := runGit(ctx, dir, "remote", "get-url", "origin")
+	if err != nil {
+		return nil, err
+	}
+	remote, err := ParseGitRemoteURL(remoteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	branch, err := currentBranch(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	return &GitCheckout{Remote: remote, Branch: branch}, nil
+}
+
+// CurrentPR finds the active PR for the checked-out branch.
+func (c *Client) CurrentPR(ctx context.Context, checkout *GitCheckout) (*ParsedPR, error) {
+	remote := checkout.Remote
+	c.api.Debugf("Looking up active PR for %s in %s/%s/%s", checkout.Branch, remote.Organization, remote.Project, remote.Repository)
+
+	prID, err := c.FindActivePR(ctx, remote, checkout.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	prURL := remote.PRURL(prID)
+	c.api.Debugf("Using PR: %s", prURL)
+	return ParsePRURL(prURL)
+}
+
+// currentBranch returns the branch name on origin for the checked-out branch: the
+// upstream branch if it tracks origin, otherwise the local branch name.
+func currentBranch(ctx context.Context, dir string) (string, error) {
+	if upstream, err := runGit(ctx, dir, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"); err == nil {
+		if name, ok := strings.CutPrefix(upstream, "origin/"); ok && name != "" {
+			return name, nil
+		}
+	}
+
+	branch, err := runGit(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	if branch == "HEAD" {
+		return "", errors.New("cannot infer the pull request: HEAD is detached; check out the PR branch or pass the PR URL")
+	}
+	return branch, nil
+}
+
+// runGit runs a git command in dir and returns its trimmed stdout.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), msg)
+		}
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
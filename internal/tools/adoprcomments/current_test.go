@@ -0,0 +1,124 @@
+package adoprcomments
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestParseGitRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	want := GitRemote{BaseURL: "https://dev.azure.com", Organization: "org", Project: "project", Repository: "repo"}
+
+	tests := []struct {
+		name    string
+		remote  string
+		want    GitRemote
+		wantErr bool
+	}{
+		{name: "https", remote: "https://dev.azure.com/org/project/_git/repo", want: want},
+		{name: "https with user", remote: "https://org@dev.azure.com/org/project/_git/repo", want: want},
+		{name: "ssh scp-like", remote: "git@ssh.dev.azure.com:v3/org/project/repo", want: want},
+		{name: "ssh url", remote: "ssh://git@ssh.dev.azure.com/v3/org/project/repo", want: want},
+		{name: "visualstudio https", remote: "https://org.visualstudio.com/DefaultCollection/project/_git/repo", want: want},
+		{name: "visualstudio ssh", remote: "org@vs-ssh.visualstudio.com:v3/org/project/repo", want: want},
+		{
+			name:   "escaped segments",
+			remote: "https://dev.azure.com/org/my%20project/_git/my%20repo",
+			want:   GitRemote{BaseURL: "https://dev.azure.com", Organization: "org", Project: "my project", Repository: "my repo"},
+		},
+		{name: "github", remote: "git@github.com:org/repo.git", wantErr: true},
+		{name: "missing _git", remote: "https://dev.azure.com/org/project/repo", wantErr: true},
+		{name: "not a url", remote: "repo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseGitRemoteURL(tt.remote)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGitRemoteURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != tt.want {
+				t.Fatalf("ParseGitRemoteURL() got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitRemotePRURLRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	remote := &GitRemote{BaseURL: "https://dev.azure.com", Organization: "org", Project: "my project", Repository: "repo"}
+	got, err := ParsePRURL(remote.PRURL(42))
+	if err != nil {
+		t.Fatalf("ParsePRURL: %v", err)
+	}
+	want := ParsedPR{BaseURL: "https://dev.azure.com", Organization: "org", Project: "my project", Repository: "repo", PRID: "42"}
+	if *got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestClientFindActivePR(t *testing.T) {
+	t.Parallel()
+
+	remote := &GitRemote{Organization: "org", Project: "project", Repository: "repo"}
+
+	tests := []struct {
+		name    string
+		prs     []PRResponse
+		want    int
+		wantErr string
+	}{
+		{name: "single", prs: []PRResponse{{PullRequestID: 7}}, want: 7},
+		{name: "none", wantErr: "no active pull request"},
+		{name: "multiple", prs: []PRResponse{{PullRequestID: 7}, {PullRequestID: 9}}, wantErr: "7, 9"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
+			api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if !strings.HasSuffix(r.URL.Path, "/org/project/_apis/git/repositories/repo/pullrequests") {
+					t.Fatalf("unexpected request: %s", r.URL)
+				}
+				q := r.URL.Query()
+				if got := q.Get("searchCriteria.sourceRefName"); got != "refs/heads/feature/x" {
+					t.Fatalf("sourceRefName = %q", got)
+				}
+				if got := q.Get("searchCriteria.status"); got != "active" {
+					t.Fatalf("status = %q", got)
+				}
+				return jsonResponse(r, http.StatusOK, PullRequestsResponse{Value: tt.prs})
+			}))
+
+			got, err := NewClient(api).FindActivePR(context.Background(), remote, "feature/x")
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindActivePR: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -21,9 +21,10 @@ const (
 
 // Config holds all configuration for the ado-pr-comments tool.
 type Config struct {
-	Filter *FilterConfig `json:"filter,omitempty"`
-	Output *OutputConfig `json:"output,omitempty"`
-	Status *StatusConfig `json:"status,omitempty"`
+	Filter   *FilterConfig   `json:"filter,omitempty"`
+	Output   *OutputConfig   `json:"output,omitempty"`
+	Status   *StatusConfig   `json:"status,omitempty"`
+	PROutput *PROutputConfig `json:"prOutput,omitempty"`
 }
 
 // StatusConfig controls which thread statuses are included in output.
@@ -44,12 +45,17 @@ type FilterConfig struct {
 }
 
 // OutputConfig controls which fields are included in output.
+// Field names match the map keys emitted in TOON mode.
 type OutputConfig struct {
+	// ID applies to both thread and comment IDs, which are emitted as "id".
+	ID FieldMode `json:"id,omitempty"`
+
 	// Thread fields
-	FilePath  FieldMode `json:"filePath,omitempty"`
-	LineStart FieldMode `json:"lineStart,omitempty"`
-	LineEnd   FieldMode `json:"lineEnd,omitempty"`
-	Status    FieldMode `json:"status,omitempty"`
+	FilePath    FieldMode `json:"filePath,omitempty"`
+	LineStart   FieldMode `json:"lineStart,omitempty"`
+	LineEnd     FieldMode `json:"lineEnd,omitempty"`
+	Status      FieldMode `json:"status,omitempty"`
+	CodeContext FieldMode `json:"codeContext,omitempty"`
 
 	// Comment fields
 	Author    FieldMode `json:"author,omitempty"`
@@ -59,6 +65,31 @@ type OutputConfig struct {
 	Content   FieldMode `json:"content,omitempty"`
 }
 
+// PROutputConfig controls which fields are included in ado-pr output.
+// Field names match the map keys emitted in TOON mode.
+type PROutputConfig struct {
+	// Pull request fields
+	ID           FieldMode `json:"id,omitempty"`
+	Title        FieldMode `json:"title,omitempty"`
+	Description  FieldMode `json:"description,omitempty"`
+	Author       FieldMode `json:"author,omitempty"`
+	Status       FieldMode `json:"status,omitempty"`
+	IsDraft      FieldMode `json:"isDraft,omitempty"`
+	SourceBranch FieldMode `json:"sourceBranch,omitempty"`
+	TargetBranch FieldMode `json:"targetBranch,omitempty"`
+	MergeStatus  FieldMode `json:"mergeStatus,omitempty"`
+	Created      FieldMode `json:"created,omitempty"`
+	WorkItems    FieldMode `json:"workItems,omitempty"`
+
+	// Sections
+	Reviewers FieldMode `json:"reviewers,omitempty"`
+
+	// Reviewer fields
+	ReviewerName     FieldMode `json:"name,omitempty"`
+	ReviewerVote     FieldMode `json:"vote,omitempty"`
+	ReviewerRequired FieldMode `json:"required,omitempty"`
+}
+
 // CompiledFilter holds compiled regex patterns for efficient filtering.
 type CompiledFilter struct {
 	cutPatterns    []*regexp.Regexp
@@ -87,18 +118,90 @@ func DefaultStatusConfig() *StatusConfig {
 // All fields default to "notEmpty".
 func DefaultOutputConfig() *OutputConfig {
 	return &OutputConfig{
-		FilePath:  FieldModeNotEmpty,
-		LineStart: FieldModeNotEmpty,
-		LineEnd:   FieldModeNotEmpty,
-		Status:    FieldModeNotEmpty,
-		Author:    FieldModeNotEmpty,
-		Published: FieldModeNotEmpty,
-		Updated:   FieldModeNotEmpty,
-		Type:      FieldModeNotEmpty,
-		Content:   FieldModeNotEmpty,
+		ID:          FieldModeNotEmpty,
+		FilePath:    FieldModeNotEmpty,
+		LineStart:   FieldModeNotEmpty,
+		LineEnd:     FieldModeNotEmpty,
+		Status:      FieldModeNotEmpty,
+		CodeContext: FieldModeNotEmpty,
+		Author:      FieldModeNotEmpty,
+		Published:   FieldModeNotEmpty,
+		Updated:     FieldModeNotEmpty,
+		Type:        FieldModeNotEmpty,
+		Content:     FieldModeNotEmpty,
+	}
+}
+
+// DefaultPROutputConfig returns the default ado-pr output config.
+// All fields default to "notEmpty".
+func DefaultPROutputConfig() *PROutputConfig {
+	return &PROutputConfig{
+		ID:           FieldModeNotEmpty,
+		Title:        FieldModeNotEmpty,
+		Description:  FieldModeNotEmpty,
+		Author:       FieldModeNotEmpty,
+		Status:       FieldModeNotEmpty,
+		IsDraft:      FieldModeNotEmpty,
+		SourceBranch: FieldModeNotEmpty,
+		TargetBranch: FieldModeNotEmpty,
+		MergeStatus:  FieldModeNotEmpty,
+		Created:      FieldModeNotEmpty,
+		WorkItems:    FieldModeNotEmpty,
+
+		Reviewers: FieldModeNotEmpty,
+
+		ReviewerName:     FieldModeNotEmpty,
+		ReviewerVote:     FieldModeNotEmpty,
+		ReviewerRequired: FieldModeNotEmpty,
 	}
 }
 
+// GetFieldMode returns the mode for a field, defaulting to notEmpty if not set.
+func (oc *PROutputConfig) GetFieldMode(field string) FieldMode {
+	if oc == nil {
+		return FieldModeNotEmpty
+	}
+
+	var mode FieldMode
+	switch field {
+	case "id":
+		mode = oc.ID
+	case "title":
+		mode = oc.Title
+	case "description":
+		mode = oc.Description
+	case "author":
+		mode = oc.Author
+	case "status":
+		mode = oc.Status
+	case "isDraft":
+		mode = oc.IsDraft
+	case "sourceBranch":
+		mode = oc.SourceBranch
+	case "targetBranch":
+		mode = oc.TargetBranch
+	case "mergeStatus":
+		mode = oc.MergeStatus
+	case "created":
+		mode = oc.Created
+	case "workItems":
+		mode = oc.WorkItems
+	case "reviewers":
+		mode = oc.Reviewers
+	case "name":
+		mode = oc.ReviewerName
+	case "vote":
+		mode = oc.ReviewerVote
+	case "required":
+		mode = oc.ReviewerRequired
+	}
+
+	if mode == "" {
+		return FieldModeNotEmpty
+	}
+	return mode
+}
+
 // GetFieldMode returns the mode for a field, defaulting to notEmpty if not set.
 func (oc *OutputConfig) GetFieldMode(field string) FieldMode {
 	if oc == nil {
@@ -107,6 +210,8 @@ func (oc *OutputConfig) GetFieldMode(field string) FieldMode {
 
 	var mode FieldMode
 	switch field {
+	case "id":
+		mode = oc.ID
 	case "filePath":
 		mode = oc.FilePath
 	case "lineStart":
@@ -115,6 +220,8 @@ func (oc *OutputConfig) GetFieldMode(field string) FieldMode {
 		mode = oc.LineEnd
 	case "status":
 		mode = oc.Status
+	case "codeContext":
+		mode = oc.CodeContext
 	case "author":
 		mode = oc.Author
 	case "published":
@@ -133,16 +240,18 @@ func (oc *OutputConfig) GetFieldMode(field string) FieldMode {
 	return mode
 }
 
-// LoadConfig loads the ado-pr-comments config from ~/.toolbox/ado-pr-comments.json.
-// Falls back to defaults if file doesn't exist.
-func LoadConfig() (*Config, error) {
+// LoadConfig loads the ado-pr-comments config from ~/.toolbox/ado-pr-comments.json and the
+// repository's .toolbox/ado-pr-comments.json, layered over the "ado-pr-comments" section of the
+// profile for org (see config.LoadForOrg). Falls back to defaults if none exists.
+func LoadConfig(org string) (*Config, error) {
 	var cfg Config
-	err := config.Load(configFile, &cfg)
+	err := config.LoadForOrg(org, configFile, &cfg)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &Config{
-				Filter: DefaultFilterConfig(),
-				Output: DefaultOutputConfig(),
+				Filter:   DefaultFilterConfig(),
+				Output:   DefaultOutputConfig(),
+				PROutput: DefaultPROutputConfig(),
 			}, nil
 		}
 		return nil, err
@@ -158,6 +267,9 @@ func LoadConfig() (*Config, error) {
 	if cfg.Status == nil {
 		cfg.Status = DefaultStatusConfig()
 	}
+	if cfg.PROutput == nil {
+		cfg.PROutput = DefaultPROutputConfig()
+	}
 
 	return &cfg, nil
 }
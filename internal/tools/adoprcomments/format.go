@@ -21,15 +21,18 @@ var (
 
 // SimplifiedThread represents a simplified view of a PR thread.
 type SimplifiedThread struct {
-	FilePath  string              `json:"filePath,omitempty"`
-	LineStart *int                `json:"lineStart,omitempty"`
-	LineEnd   *int                `json:"lineEnd,omitempty"`
-	Status    string              `json:"status,omitempty"`
-	Comments  []SimplifiedComment `json:"comments"`
+	ID          int                 `json:"id,omitempty"`
+	FilePath    string              `json:"filePath,omitempty"`
+	LineStart   *int                `json:"lineStart,omitempty"`
+	LineEnd     *int                `json:"lineEnd,omitempty"`
+	Status      string              `json:"status,omitempty"`
+	CodeContext string              `json:"codeContext,omitempty"`
+	Comments    []SimplifiedComment `json:"comments"`
 }
 
 // SimplifiedComment represents a simplified view of a comment.
 type SimplifiedComment struct {
+	ID        int    `json:"id,omitempty"`
 	Author    string `json:"author,omitempty"`
 	Published string `json:"published,omitempty"`
 	Updated   string `json:"updated,omitempty"`
@@ -43,6 +46,7 @@ func SimplifyThreads(threads []Thread, filter *CompiledFilter) []SimplifiedThrea
 
 	for _, thread := range threads {
 		simplified := SimplifiedThread{
+			ID:       thread.ID,
 			Status:   thread.Status,
 			Comments: make([]SimplifiedComment, 0, len(thread.Comments)),
 		}
@@ -68,23 +72,7 @@ func SimplifyThreads(threads []Thread, filter *CompiledFilter) []SimplifiedThrea
 
 		// Simplify comments
 		for _, comment := range thread.Comments {
-			sc := SimplifiedComment{
-				Published: comment.PublishedDate,
-				Updated:   comment.LastUpdatedDate,
-				Type:      comment.CommentType,
-			}
-
-			if comment.Author != nil {
-				sc.Author = comment.Author.DisplayName
-			}
-
-			content := normalizeContent(comment.Content)
-			if filter != nil && filter.ShouldFilter(sc.Author) {
-				content = filter.Apply(content)
-			}
-			sc.Content = content
-
-			simplified.Comments = append(simplified.Comments, sc)
+			simplified.Comments = append(simplified.Comments, SimplifyComment(comment, filter))
 		}
 
 		result = append(result, simplified)
@@ -93,11 +81,36 @@ func SimplifyThreads(threads []Thread, filter *CompiledFilter) []SimplifiedThrea
 	return result
 }
 
+// SimplifyComment converts a raw API comment to simplified format.
+func SimplifyComment(comment Comment, filter *CompiledFilter) SimplifiedComment {
+	sc := SimplifiedComment{
+		ID:        comment.ID,
+		Published: comment.PublishedDate,
+		Updated:   comment.LastUpdatedDate,
+		Type:      comment.CommentType,
+	}
+
+	if comment.Author != nil {
+		sc.Author = comment.Author.DisplayName
+	}
+
+	content := normalizeContent(comment.Content)
+	if filter != nil && filter.ShouldFilter(sc.Author) {
+		content = filter.Apply(content)
+	}
+	sc.Content = content
+
+	return sc
+}
+
 // ThreadToMap converts a SimplifiedThread to a map based on output config.
 // This allows dynamic field inclusion for TOON output.
 func ThreadToMap(t SimplifiedThread, cfg *OutputConfig) map[string]any {
 	m := make(map[string]any)
 
+	if shouldInclude(cfg, "id", t.ID != 0) {
+		m["id"] = t.ID
+	}
 	if shouldInclude(cfg, "filePath", t.FilePath != "") {
 		m["filePath"] = t.FilePath
 	}
@@ -110,6 +123,9 @@ func ThreadToMap(t SimplifiedThread, cfg *OutputConfig) map[string]any {
 	if shouldInclude(cfg, "status", t.Status != "") {
 		m["status"] = t.Status
 	}
+	if shouldInclude(cfg, "codeContext", t.CodeContext != "") {
+		m["codeContext"] = t.CodeContext
+	}
 
 	// Always include comments array, but filter comment fields
 	comments := make([]map[string]any, 0, len(t.Comments))
@@ -125,6 +141,9 @@ func ThreadToMap(t SimplifiedThread, cfg *OutputConfig) map[string]any {
 func CommentToMap(c SimplifiedComment, cfg *OutputConfig) map[string]any {
 	m := make(map[string]any)
 
+	if shouldInclude(cfg, "id", c.ID != 0) {
+		m["id"] = c.ID
+	}
 	if shouldInclude(cfg, "author", c.Author != "") {
 		m["author"] = c.Author
 	}
@@ -153,8 +172,13 @@ func ThreadsToMaps(threads []SimplifiedThread, cfg *OutputConfig) []map[string]a
 	return result
 }
 
+// fieldModeConfig is implemented by output configs that map field names to modes.
+type fieldModeConfig interface {
+	GetFieldMode(field string) FieldMode
+}
+
 // shouldInclude determines if a field should be included based on config and value.
-func shouldInclude(cfg *OutputConfig, field string, hasValue bool) bool {
+func shouldInclude(cfg fieldModeConfig, field string, hasValue bool) bool {
 	mode := cfg.GetFieldMode(field)
 	switch mode {
 	case FieldModeAlways:
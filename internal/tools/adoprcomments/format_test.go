@@ -47,3 +47,38 @@ func TestNormalizeContent(t *testing.T) {
 		}
 	})
 }
+
+func TestSimplifyThreadsIncludesIDs(t *testing.T) {
+	t.Parallel()
+
+	threads := []Thread{
+		{
+			ID:     7,
+			Status: "active",
+			Comments: []Comment{
+				{ID: 1, Content: "first"},
+				{ID: 2, Content: "second"},
+			},
+		},
+	}
+
+	got := SimplifyThreads(threads, nil)
+	if len(got) != 1 || got[0].ID != 7 {
+		t.Fatalf("threads=%+v, want one thread with id 7", got)
+	}
+	if len(got[0].Comments) != 2 || got[0].Comments[0].ID != 1 || got[0].Comments[1].ID != 2 {
+		t.Fatalf("comments=%+v, want ids 1 and 2", got[0].Comments)
+	}
+
+	m := ThreadToMap(got[0], DefaultOutputConfig())
+	if m["id"] != 7 {
+		t.Fatalf("map id=%v, want 7", m["id"])
+	}
+	hidden := ThreadToMap(got[0], &OutputConfig{ID: FieldModeNever})
+	if _, ok := hidden["id"]; ok {
+		t.Fatalf("thread id should be omitted when id is never")
+	}
+	if _, ok := hidden["comments"].([]map[string]any)[0]["id"]; ok {
+		t.Fatalf("comment id should be omitted when id is never")
+	}
+}
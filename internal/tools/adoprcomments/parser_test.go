@@ -15,6 +15,7 @@ func TestParsePRURL(t *testing.T) {
 			name:   "dev.azure.com format",
 			rawURL: "https://dev.azure.com/org/project/_git/repo/pullrequest/123",
 			want: &ParsedPR{
+				BaseURL:      "https://dev.azure.com",
 				Organization: "org",
 				Project:      "project",
 				Repository:   "repo",
@@ -25,6 +26,7 @@ func TestParsePRURL(t *testing.T) {
 			name:   "visualstudio.com format",
 			rawURL: "https://org.visualstudio.com/project/_git/repo/pullrequest/123",
 			want: &ParsedPR{
+				BaseURL:      "https://dev.azure.com",
 				Organization: "org",
 				Project:      "project",
 				Repository:   "repo",
@@ -35,6 +37,7 @@ func TestParsePRURL(t *testing.T) {
 			name:   "path-unescapes segments",
 			rawURL: "https://dev.azure.com/org/my%20project/_git/my%20repo/pullrequest/123",
 			want: &ParsedPR{
+				BaseURL:      "https://dev.azure.com",
 				Organization: "org",
 				Project:      "my project",
 				Repository:   "my repo",
@@ -0,0 +1,210 @@
+package adoprcomments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PROptions configures fetching pull request metadata.
+type PROptions struct {
+	Ctx        context.Context
+	PRURL      string
+	OutputJSON bool // Output JSON instead of toon
+	Debug      bool
+	DebugLog   func(string)
+}
+
+// PRResult contains the output from fetching pull request metadata.
+type PRResult struct {
+	PR     SimplifiedPR
+	Output string // Formatted output (toon or JSON)
+}
+
+// SimplifiedPR represents a simplified view of a pull request.
+type SimplifiedPR struct {
+	ID           int                  `json:"id,omitempty"`
+	Title        string               `json:"title,omitempty"`
+	Description  string               `json:"description,omitempty"`
+	Author       string               `json:"author,omitempty"`
+	Status       string               `json:"status,omitempty"`
+	IsDraft      bool                 `json:"isDraft,omitempty"`
+	SourceBranch string               `json:"sourceBranch,omitempty"`
+	TargetBranch string               `json:"targetBranch,omitempty"`
+	MergeStatus  string               `json:"mergeStatus,omitempty"`
+	Created      string               `json:"created,omitempty"`
+	Reviewers    []SimplifiedReviewer `json:"reviewers"`
+	WorkItems    []int                `json:"workItems"`
+}
+
+// SimplifiedReviewer represents a reviewer and their vote.
+type SimplifiedReviewer struct {
+	Name     string `json:"name,omitempty"`
+	Vote     string `json:"vote,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// GetPR fetches pull request metadata from Azure DevOps.
+func GetPR(opts PROptions) (*PRResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	parsed, err := ParsePRURL(opts.PRURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig(parsed.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newRunClient(parsed.BaseURL, parsed.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	prResp, err := client.FetchPRDetails(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	simplified := SimplifyPR(*prResp)
+	output, err := marshalOutput(simplified, PRToMap(simplified, cfg.PROutput), opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PRResult{
+		PR:     simplified,
+		Output: output,
+	}, nil
+}
+
+// SimplifyPR converts a raw PR response to simplified format.
+func SimplifyPR(pr PRResponse) SimplifiedPR {
+	s := SimplifiedPR{
+		ID:           pr.PullRequestID,
+		Title:        strings.TrimSpace(pr.Title),
+		Description:  normalizeContent(pr.Description),
+		Status:       pr.Status,
+		IsDraft:      pr.IsDraft,
+		SourceBranch: shortRefName(pr.SourceRefName),
+		TargetBranch: shortRefName(pr.TargetRefName),
+		MergeStatus:  pr.MergeStatus,
+		Created:      pr.CreationDate,
+		Reviewers:    make([]SimplifiedReviewer, 0, len(pr.Reviewers)),
+		WorkItems:    make([]int, 0, len(pr.WorkItemRefs)),
+	}
+	if pr.CreatedBy != nil {
+		s.Author = pr.CreatedBy.DisplayName
+	}
+
+	for _, r := range pr.Reviewers {
+		s.Reviewers = append(s.Reviewers, SimplifiedReviewer{
+			Name:     r.DisplayName,
+			Vote:     voteLabel(r.Vote, r.HasDeclined),
+			Required: r.IsRequired,
+		})
+	}
+
+	for _, ref := range pr.WorkItemRefs {
+		if id, err := strconv.Atoi(ref.ID); err == nil {
+			s.WorkItems = append(s.WorkItems, id)
+		}
+	}
+	sort.Ints(s.WorkItems)
+
+	return s
+}
+
+// shortRefName strips the refs/heads/ prefix from a branch ref.
+func shortRefName(ref string) string {
+	return strings.TrimPrefix(ref, "refs/heads/")
+}
+
+// voteLabel converts an ADO reviewer vote to a readable label.
+func voteLabel(vote int, declined bool) string {
+	switch {
+	case vote >= 10:
+		return "approved"
+	case vote >= 5:
+		return "approvedWithSuggestions"
+	case vote <= -10:
+		return "rejected"
+	case vote <= -5:
+		return "waitingForAuthor"
+	case declined:
+		return "declined"
+	default:
+		return "noVote"
+	}
+}
+
+// PRToMap converts a SimplifiedPR to a map based on output config.
+func PRToMap(p SimplifiedPR, cfg *PROutputConfig) map[string]any {
+	m := make(map[string]any)
+
+	if shouldInclude(cfg, "id", p.ID != 0) {
+		m["id"] = p.ID
+	}
+	if shouldInclude(cfg, "title", p.Title != "") {
+		m["title"] = p.Title
+	}
+	if shouldInclude(cfg, "author", p.Author != "") {
+		m["author"] = p.Author
+	}
+	if shouldInclude(cfg, "status", p.Status != "") {
+		m["status"] = p.Status
+	}
+	if shouldInclude(cfg, "isDraft", p.IsDraft) {
+		m["isDraft"] = p.IsDraft
+	}
+	if shouldInclude(cfg, "sourceBranch", p.SourceBranch != "") {
+		m["sourceBranch"] = p.SourceBranch
+	}
+	if shouldInclude(cfg, "targetBranch", p.TargetBranch != "") {
+		m["targetBranch"] = p.TargetBranch
+	}
+	if shouldInclude(cfg, "mergeStatus", p.MergeStatus != "") {
+		m["mergeStatus"] = p.MergeStatus
+	}
+	if shouldInclude(cfg, "created", p.Created != "") {
+		m["created"] = p.Created
+	}
+	if shouldInclude(cfg, "workItems", len(p.WorkItems) > 0) {
+		m["workItems"] = p.WorkItems
+	}
+	if shouldInclude(cfg, "description", p.Description != "") {
+		m["description"] = p.Description
+	}
+
+	if shouldInclude(cfg, "reviewers", len(p.Reviewers) > 0) {
+		reviewers := make([]map[string]any, 0, len(p.Reviewers))
+		for _, r := range p.Reviewers {
+			reviewers = append(reviewers, ReviewerToMap(r, cfg))
+		}
+		m["reviewers"] = reviewers
+	}
+
+	return m
+}
+
+// ReviewerToMap converts a SimplifiedReviewer to a map based on output config.
+func ReviewerToMap(r SimplifiedReviewer, cfg *PROutputConfig) map[string]any {
+	m := make(map[string]any)
+	if shouldInclude(cfg, "name", r.Name != "") {
+		m["name"] = r.Name
+	}
+	if shouldInclude(cfg, "vote", r.Vote != "") {
+		m["vote"] = r.Vote
+	}
+	if shouldInclude(cfg, "required", r.Required) {
+		m["required"] = r.Required
+	}
+	return m
+}
@@ -0,0 +1,64 @@
+package adoprcomments
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimplifyPR(t *testing.T) {
+	t.Parallel()
+
+	got := SimplifyPR(PRResponse{
+		PullRequestID: 123,
+		Title:         " Add retries ",
+		Description:   "<p>Adds <b>retries</b></p>",
+		Status:        "active",
+		IsDraft:       true,
+		MergeStatus:   "succeeded",
+		SourceRefName: "refs/heads/feature/retries",
+		TargetRefName: "refs/heads/main",
+		CreatedBy:     &Author{DisplayName: "Jane Doe"},
+		Reviewers: []Reviewer{
+			{DisplayName: "A", Vote: 10, IsRequired: true},
+			{DisplayName: "B", Vote: -5},
+			{DisplayName: "C", Vote: 0, HasDeclined: true},
+		},
+		WorkItemRefs: []ResourceRef{{ID: "42"}, {ID: "7"}, {ID: "bogus"}},
+	})
+
+	want := SimplifiedPR{
+		ID:           123,
+		Title:        "Add retries",
+		Description:  "Adds retries",
+		Author:       "Jane Doe",
+		Status:       "active",
+		IsDraft:      true,
+		SourceBranch: "feature/retries",
+		TargetBranch: "main",
+		MergeStatus:  "succeeded",
+		Reviewers: []SimplifiedReviewer{
+			{Name: "A", Vote: "approved", Required: true},
+			{Name: "B", Vote: "waitingForAuthor"},
+			{Name: "C", Vote: "declined"},
+		},
+		WorkItems: []int{7, 42},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v\nwant %+v", got, want)
+	}
+
+	m := PRToMap(got, &PROutputConfig{Description: FieldModeNever})
+	if _, ok := m["description"]; ok {
+		t.Fatalf("description should be omitted when mode is never")
+	}
+	if m["sourceBranch"] != "feature/retries" {
+		t.Fatalf("sourceBranch=%v", m["sourceBranch"])
+	}
+
+	// Reviewer keys match the JSON output.
+	reviewers := m["reviewers"].([]map[string]any)
+	wantReviewer := map[string]any{"name": "A", "vote": "approved", "required": true}
+	if !reflect.DeepEqual(reviewers[0], wantReviewer) {
+		t.Fatalf("reviewer = %v, want %v", reviewers[0], wantReviewer)
+	}
+}
@@ -0,0 +1,79 @@
+package adoprcomments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ReplyOptions configures posting a reply to a PR comment thread.
+type ReplyOptions struct {
+	Ctx             context.Context
+	PRURL           string
+	ThreadID        int
+	ParentCommentID int // Optional comment to reply to (0 = no parent)
+	Message         string
+	OutputJSON      bool // Output JSON instead of toon
+	Debug           bool
+	DebugLog        func(string)
+}
+
+// ReplyResult contains the comment created by a reply.
+type ReplyResult struct {
+	ThreadID int               `json:"threadId"`
+	Comment  SimplifiedComment `json:"comment"`
+	Output   string            `json:"-"` // Formatted output (toon or JSON)
+}
+
+// Reply posts a comment to an existing PR thread.
+func Reply(opts ReplyOptions) (*ReplyResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if opts.ThreadID <= 0 {
+		return nil, errors.New("thread id must be a positive integer")
+	}
+	message := strings.TrimSpace(opts.Message)
+	if message == "" {
+		return nil, errors.New("message is required")
+	}
+
+	parsed, err := ParsePRURL(opts.PRURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig(parsed.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newRunClient(parsed.BaseURL, parsed.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.PostComment(ctx, parsed, opts.ThreadID, message, opts.ParentCommentID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReplyResult{
+		ThreadID: opts.ThreadID,
+		Comment:  SimplifyComment(*created, nil),
+	}
+
+	toonValue := map[string]any{
+		"threadId": result.ThreadID,
+		"comment":  CommentToMap(result.Comment, cfg.Output),
+	}
+	result.Output, err = marshalOutput(result, toonValue, opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
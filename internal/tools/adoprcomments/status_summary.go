@@ -15,6 +15,23 @@ var preferredStatusOrder = []string{
 	"closed",
 }
 
+// ValidStatuses returns the thread statuses accepted by Azure DevOps, in display order.
+func ValidStatuses() []string {
+	return append([]string(nil), preferredStatusOrder...)
+}
+
+// NormalizeStatus matches s case-insensitively against the known thread statuses
+// and returns the canonical spelling (e.g. "wontfix" -> "wontFix").
+func NormalizeStatus(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	for _, status := range preferredStatusOrder {
+		if strings.EqualFold(s, status) {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid thread status %q (valid: %s)", s, strings.Join(preferredStatusOrder, ", "))
+}
+
 // CountThreadsByStatus returns a map of thread status -> count.
 func CountThreadsByStatus(threads []Thread) map[string]int {
 	counts := make(map[string]int)
@@ -47,3 +47,33 @@ func TestEmptyStatusFilterSummary(t *testing.T) {
 		}
 	})
 }
+
+func TestNormalizeStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "fixed", want: "fixed"},
+		{in: "wontfix", want: "wontFix"},
+		{in: " ByDesign ", want: "byDesign"},
+		{in: "resolved", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := NormalizeStatus(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeStatus(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeStatus(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package adoprcomments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// SetStatusOptions configures changing the status of a PR comment thread.
+type SetStatusOptions struct {
+	Ctx        context.Context
+	PRURL      string
+	ThreadID   int
+	Status     string // One of ValidStatuses (matched case-insensitively)
+	OutputJSON bool   // Output JSON instead of toon
+	Debug      bool
+	NoFilter   bool // Disable content filtering
+	DebugLog   func(string)
+}
+
+// SetStatusResult contains the thread after its status was changed.
+type SetStatusResult struct {
+	Thread SimplifiedThread
+	Output string // Formatted output (toon or JSON)
+}
+
+// SetStatus updates the status of an existing PR thread (e.g. marks it fixed).
+func SetStatus(opts SetStatusOptions) (*SetStatusResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if opts.ThreadID <= 0 {
+		return nil, errors.New("thread id must be a positive integer")
+	}
+	status, err := NormalizeStatus(opts.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := ParsePRURL(opts.PRURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig(parsed.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	var filter *CompiledFilter
+	if !opts.NoFilter {
+		filter, err = cfg.Filter.Compile()
+		if err != nil {
+			return nil, fmt.Errorf("compile filter config: %w", err)
+		}
+	}
+
+	client, err := newRunClient(parsed.BaseURL, parsed.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := client.UpdateThreadStatus(ctx, parsed, opts.ThreadID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	simplified := SimplifyThreads([]Thread{*updated}, filter)[0]
+	output, err := marshalOutput(simplified, ThreadToMap(simplified, cfg.Output), opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetStatusResult{
+		Thread: simplified,
+		Output: output,
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/krubenok/toolbox/internal/ado"
 	"github.com/krubenok/toolbox/internal/auth"
 	toon "github.com/toon-format/toon-go"
 )
@@ -16,9 +17,23 @@ type Options struct {
 	IncludeDescription bool
 	IncludeDiscussion  bool
 	IncludeChildren    bool
+	IncludeLinks       bool
 	IncludeAttachments bool
 	MaxComments        int
 
+	// DownloadAttachments downloads attachments into the local cache, inlining small text
+	// attachments and returning images in Result.Images.
+	DownloadAttachments bool
+
+	// Fields lists additional field reference names to include, on top of those in the config file.
+	Fields []string
+
+	// ChildDepth resolves child links recursively into a nested tree of this many levels
+	// (0 = direct child links only, without titles or states).
+	ChildDepth int
+	// MaxChildNodes caps the number of descendants fetched when ChildDepth > 0 (0 = default of 500).
+	MaxChildNodes int
+
 	OutputJSON bool
 	Debug      bool
 	DebugLog   func(string)
@@ -27,6 +42,8 @@ type Options struct {
 type Result struct {
 	WorkItem SimplifiedWorkItem
 	Output   string
+	// Images holds downloaded image attachments (only with DownloadAttachments).
+	Images []AttachmentImage
 }
 
 func Run(opts Options) (*Result, error) {
@@ -40,21 +57,16 @@ func Run(opts Options) (*Result, error) {
 		return nil, err
 	}
 
-	azAuth, err := auth.GetAzureAuth()
+	cfg, err := LoadConfig(parsed.Organization)
 	if err != nil {
-		return nil, err
-	}
-	if opts.Debug && opts.DebugLog != nil {
-		opts.DebugLog("Auth: " + azAuth.Scheme)
+		return nil, fmt.Errorf("load config: %w", err)
 	}
 
-	cfg, err := LoadConfig()
+	client, err := newRunClient(parsed.BaseURL, parsed.Organization, opts.Debug, opts.DebugLog)
 	if err != nil {
-		return nil, fmt.Errorf("load config: %w", err)
+		return nil, err
 	}
 
-	client := NewClient(azAuth, opts.Debug, opts.DebugLog)
-
 	wi, err := client.FetchWorkItem(ctx, parsed)
 	if err != nil {
 		return nil, err
@@ -68,13 +80,30 @@ func Run(opts Options) (*Result, error) {
 		}
 	}
 
-	simplified := SimplifyWorkItem(parsed, *wi, comments, defaultBaseURL)
+	simplified := SimplifyWorkItem(parsed, *wi, comments, client.BaseURL())
+	simplified.Fields = selectFields(wi.Fields, mergeFieldNames(cfg.Fields, opts.Fields))
+	if opts.IncludeChildren && opts.ChildDepth > 0 {
+		simplified.Children, simplified.ChildrenTruncated, err = client.FetchChildTree(ctx, parsed, *wi, opts.ChildDepth, opts.MaxChildNodes)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var images []AttachmentImage
+	if opts.IncludeAttachments && opts.DownloadAttachments && len(simplified.Attachments) > 0 {
+		simplified.Attachments, images, err = client.DownloadAttachments(ctx, simplified.Attachments, cfg.AttachmentDownload)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if !opts.IncludeDescription {
 		simplified.Description = ""
 	}
 	if !opts.IncludeChildren {
 		simplified.Children = []SimplifiedChildLink{}
 	}
+	if !opts.IncludeLinks {
+		simplified.Links = emptyLinks()
+	}
 	if !opts.IncludeAttachments {
 		simplified.Attachments = []SimplifiedAttachment{}
 	}
@@ -82,32 +111,52 @@ func Run(opts Options) (*Result, error) {
 		simplified.Discussion = []SimplifiedComment{}
 	}
 
-	var output string
-	if opts.OutputJSON {
-		b, err := json.MarshalIndent(simplified, "", "  ")
-		if err != nil {
-			return nil, err
-		}
-		output = string(b)
-	} else {
-		m := WorkItemToMap(simplified, cfg.Output)
-		toonStr, err := toon.MarshalString(m)
-		if err != nil {
-			if opts.Debug && opts.DebugLog != nil {
-				opts.DebugLog("Warning: toon encoding failed, falling back to JSON: " + err.Error())
-			}
-			b, err := json.MarshalIndent(simplified, "", "  ")
-			if err != nil {
-				return nil, err
-			}
-			output = string(b)
-		} else {
-			output = toonStr
-		}
+	output, err := marshalOutput(simplified, WorkItemToMap(simplified, cfg.Output), opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Result{
 		WorkItem: simplified,
 		Output:   output,
+		Images:   images,
 	}, nil
 }
+
+// newRunClient resolves auth and creates a configured client for a single tool invocation
+// against the given base URL and organization (see ParsedWorkItem).
+func newRunClient(baseURL, org string, debug bool, debugLog func(string)) (*Client, error) {
+	azAuth, err := auth.GetAzureAuth(auth.Target{BaseURL: baseURL, Organization: org})
+	if err != nil {
+		return nil, err
+	}
+	if debug && debugLog != nil {
+		debugLog("Auth: " + azAuth.Scheme + " (" + azAuth.Source + ")")
+	}
+
+	api, err := ado.NewConfiguredClient(azAuth, baseURL, debug, debugLog)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(api), nil
+}
+
+// marshalOutput serializes jsonValue as indented JSON when outputJSON is set,
+// otherwise serializes toonValue as TOON, falling back to JSON if TOON encoding fails.
+func marshalOutput(jsonValue, toonValue any, outputJSON, debug bool, debugLog func(string)) (string, error) {
+	if !outputJSON {
+		toonStr, err := toon.MarshalString(toonValue)
+		if err == nil {
+			return toonStr, nil
+		}
+		if debug && debugLog != nil {
+			debugLog("Warning: toon encoding failed, falling back to JSON: " + err.Error())
+		}
+	}
+
+	b, err := json.MarshalIndent(jsonValue, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
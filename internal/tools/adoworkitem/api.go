@@ -2,132 +2,78 @@ package adoworkitem
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
-	"time"
 
-	"github.com/krubenok/toolbox/internal/auth"
+	"github.com/krubenok/toolbox/internal/ado"
 )
 
-const (
-	defaultHTTPTimeout = 30 * time.Second
-	defaultBaseURL     = "https://dev.azure.com"
-)
-
-// HTTPError represents a non-2xx response from the Azure DevOps API.
-type HTTPError struct {
-	StatusCode int
-	Status     string
-	URL        string
-	Body       string
-}
+// apiVersion is the Azure DevOps REST API version used for work item endpoints.
+const apiVersion = "7.1-preview.3"
 
-func (e *HTTPError) Error() string {
-	if e == nil {
-		return "http error"
-	}
-	if e.Body == "" {
-		return fmt.Sprintf("request failed (%s): %s", e.Status, e.URL)
-	}
-	return fmt.Sprintf("request failed (%s): %s: %s", e.Status, e.URL, e.Body)
-}
+// identitiesAPIVersion is the API version used for identity lookups.
+const identitiesAPIVersion = "7.1"
 
+// Client handles Azure DevOps work item API requests.
 type Client struct {
-	auth       *auth.Auth
-	baseURL    string
-	httpClient *http.Client
-	debug      bool
-	debugLog   func(string)
+	api *ado.Client
 }
 
-func NewClient(azAuth *auth.Auth, debug bool, debugLog func(string)) *Client {
-	return NewClientWithBaseURL(azAuth, defaultBaseURL, debug, debugLog)
+// NewClient creates a new work item client backed by the shared ADO client.
+func NewClient(api *ado.Client) *Client {
+	return &Client{api: api}
 }
 
-func NewClientWithBaseURL(azAuth *auth.Auth, baseURL string, debug bool, debugLog func(string)) *Client {
-	baseURL = strings.TrimRight(baseURL, "/")
-	if baseURL == "" {
-		baseURL = defaultBaseURL
-	}
-
-	return &Client{
-		auth:       azAuth,
-		baseURL:    baseURL,
-		httpClient: &http.Client{Timeout: defaultHTTPTimeout},
-		debug:      debug,
-		debugLog:   debugLog,
-	}
-}
-
-func (c *Client) fetchJSON(ctx context.Context, apiURL string, result any) error {
-	if c.debug && c.debugLog != nil {
-		c.debugLog(fmt.Sprintf("Fetching: %s", apiURL))
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", c.auth.AuthorizationHeader())
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
-		return &HTTPError{
-			StatusCode: resp.StatusCode,
-			Status:     resp.Status,
-			URL:        apiURL,
-			Body:       strings.TrimSpace(string(bodyBytes)),
-		}
-	}
-
-	return json.NewDecoder(resp.Body).Decode(result)
+// BaseURL returns the base URL of the underlying ADO client.
+func (c *Client) BaseURL() string {
+	return c.api.BaseURL()
 }
 
 func (c *Client) WorkItemURL(parsed *ParsedWorkItem) string {
-	return fmt.Sprintf(
-		"%s/%s/%s/_apis/wit/workitems/%d?$expand=relations&api-version=7.1-preview.3",
-		c.baseURL,
-		url.PathEscape(parsed.Organization),
-		url.PathEscape(parsed.Project),
-		parsed.ID,
+	return c.api.URL(
+		parsed.Organization,
+		parsed.Project,
+		"wit/workitems/"+strconv.Itoa(parsed.ID),
+		apiVersion,
+		url.Values{"$expand": {"relations"}},
 	)
 }
 
 func (c *Client) WorkItemCommentsURL(parsed *ParsedWorkItem, top int, continuationToken string) string {
-	u := fmt.Sprintf(
-		"%s/%s/%s/_apis/wit/workItems/%d/comments?api-version=7.1-preview.3",
-		c.baseURL,
-		url.PathEscape(parsed.Organization),
-		url.PathEscape(parsed.Project),
-		parsed.ID,
-	)
+	query := url.Values{}
 	if top > 0 {
-		u += "&$top=" + strconv.Itoa(top)
+		query.Set("$top", strconv.Itoa(top))
 	}
 	if continuationToken != "" {
-		u += "&continuationToken=" + url.QueryEscape(continuationToken)
+		query.Set("continuationToken", continuationToken)
 	}
-	return u
+	return c.api.URL(
+		parsed.Organization,
+		parsed.Project,
+		"wit/workItems/"+strconv.Itoa(parsed.ID)+"/comments",
+		apiVersion,
+		query,
+	)
 }
 
-func UIWorkItemURL(baseURL, org, project string, id int) string {
-	baseURL = strings.TrimRight(baseURL, "/")
-	if baseURL == "" {
-		baseURL = defaultBaseURL
+// WIQLURL builds the WIQL query API URL. A non-empty team scopes team macros such as @CurrentIteration.
+func (c *Client) WIQLURL(org, project, team string, top int) string {
+	query := url.Values{}
+	if top > 0 {
+		query.Set("$top", strconv.Itoa(top))
 	}
-	return baseURL + "/" + url.PathEscape(org) + "/" + url.PathEscape(project) + "/_workitems/edit/" + strconv.Itoa(id)
+	return c.api.TeamURL(org, project, team, "wit/wiql", apiVersion, query)
+}
+
+// WorkItemsBatchURL builds the work items batch API URL.
+func (c *Client) WorkItemsBatchURL(org, project string) string {
+	return c.api.URL(org, project, "wit/workitemsbatch", apiVersion, nil)
+}
+
+func UIWorkItemURL(baseURL, org, project string, id int) string {
+	return ado.NormalizeBaseURL(baseURL) + "/" + url.PathEscape(org) + "/" + url.PathEscape(project) + "/_workitems/edit/" + strconv.Itoa(id)
 }
 
 type WorkItemResponse struct {
@@ -167,7 +113,7 @@ type IdentityRef struct {
 
 func (c *Client) FetchWorkItem(ctx context.Context, parsed *ParsedWorkItem) (*WorkItemResponse, error) {
 	var wi WorkItemResponse
-	if err := c.fetchJSON(ctx, c.WorkItemURL(parsed), &wi); err != nil {
+	if err := c.api.GetJSON(ctx, c.WorkItemURL(parsed), &wi); err != nil {
 		return nil, err
 	}
 	return &wi, nil
@@ -180,7 +126,7 @@ func (c *Client) FetchAllComments(ctx context.Context, parsed *ParsedWorkItem, m
 	var token string
 	for {
 		var resp WorkItemCommentsResponse
-		if err := c.fetchJSON(ctx, c.WorkItemCommentsURL(parsed, defaultTop, token), &resp); err != nil {
+		if err := c.api.GetJSON(ctx, c.WorkItemCommentsURL(parsed, defaultTop, token), &resp); err != nil {
 			return nil, err
 		}
 
@@ -195,3 +141,260 @@ func (c *Client) FetchAllComments(ctx context.Context, parsed *ParsedWorkItem, m
 		token = resp.ContinuationToken
 	}
 }
+
+// IdentitiesURL builds the identity search API URL used to resolve @mentions.
+// On Azure DevOps Services the identities API is served from vssps.dev.azure.com.
+func (c *Client) IdentitiesURL(org, filterValue string) string {
+	base := c.api.BaseURL()
+	if u, err := url.Parse(base); err == nil && strings.EqualFold(u.Host, "dev.azure.com") {
+		u.Host = "vssps.dev.azure.com"
+		base = u.String()
+	}
+	query := url.Values{
+		"searchFilter":    {"General"},
+		"filterValue":     {filterValue},
+		"queryMembership": {"None"},
+	}
+	return base + "/" + url.PathEscape(org) + "/_apis/identities?api-version=" + identitiesAPIVersion + "&" + query.Encode()
+}
+
+// CreateWorkItemURL builds the API URL for creating a work item of the given type.
+func (c *Client) CreateWorkItemURL(org, project, workItemType string) string {
+	return c.api.URL(
+		org,
+		project,
+		"wit/workitems/$"+url.PathEscape(workItemType),
+		apiVersion,
+		url.Values{"$expand": {"relations"}},
+	)
+}
+
+// CreateWorkItem creates a work item of the given type from a JSON Patch document.
+func (c *Client) CreateWorkItem(ctx context.Context, org, project, workItemType string, patch []PatchOperation) (*WorkItemResponse, error) {
+	var wi WorkItemResponse
+	if err := c.api.SendJSON(ctx, http.MethodPost, c.CreateWorkItemURL(org, project, workItemType), jsonPatchContentType, patch, &wi); err != nil {
+		return nil, err
+	}
+	return &wi, nil
+}
+
+// UpdateWorkItem applies a JSON Patch document to an existing work item.
+func (c *Client) UpdateWorkItem(ctx context.Context, parsed *ParsedWorkItem, patch []PatchOperation) (*WorkItemResponse, error) {
+	var wi WorkItemResponse
+	if err := c.api.SendJSON(ctx, http.MethodPatch, c.WorkItemURL(parsed), jsonPatchContentType, patch, &wi); err != nil {
+		return nil, err
+	}
+	return &wi, nil
+}
+
+// newWorkItemComment is the request body for adding a work item comment.
+type newWorkItemComment struct {
+	Text string `json:"text"`
+}
+
+// PostComment adds a comment with the given HTML text to a work item's discussion.
+func (c *Client) PostComment(ctx context.Context, parsed *ParsedWorkItem, text string) (*WorkItemComment, error) {
+	var created WorkItemComment
+	if err := c.api.SendJSON(ctx, http.MethodPost, c.WorkItemCommentsURL(parsed, 0, ""), "", newWorkItemComment{Text: text}, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// IdentitiesResponse represents the identity search API response.
+type IdentitiesResponse struct {
+	Count int        `json:"count"`
+	Value []Identity `json:"value"`
+}
+
+// Identity is an Azure DevOps user or group.
+type Identity struct {
+	ID                  string `json:"id"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	CustomDisplayName   string `json:"customDisplayName"`
+}
+
+// DisplayName returns the name shown for the identity in the web UI.
+func (i Identity) DisplayName() string {
+	if i.CustomDisplayName != "" {
+		return i.CustomDisplayName
+	}
+	return i.ProviderDisplayName
+}
+
+// FindIdentities searches the organization's identities by display name, email or account name.
+func (c *Client) FindIdentities(ctx context.Context, org, filterValue string) ([]Identity, error) {
+	var resp IdentitiesResponse
+	if err := c.api.GetJSON(ctx, c.IdentitiesURL(org, filterValue), &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// WorkItemUpdatesURL builds the work item updates (revision history) API URL.
+func (c *Client) WorkItemUpdatesURL(parsed *ParsedWorkItem, top, skip int) string {
+	query := url.Values{}
+	if top > 0 {
+		query.Set("$top", strconv.Itoa(top))
+	}
+	if skip > 0 {
+		query.Set("$skip", strconv.Itoa(skip))
+	}
+	return c.api.URL(
+		parsed.Organization,
+		parsed.Project,
+		"wit/workItems/"+strconv.Itoa(parsed.ID)+"/updates",
+		apiVersion,
+		query,
+	)
+}
+
+// WorkItemUpdatesResponse represents the work item updates API response.
+type WorkItemUpdatesResponse struct {
+	Count int              `json:"count"`
+	Value []WorkItemUpdate `json:"value"`
+}
+
+// WorkItemUpdate is a single revision of a work item with the fields and links it changed.
+type WorkItemUpdate struct {
+	ID          int                            `json:"id"`
+	Rev         int                            `json:"rev"`
+	RevisedBy   *IdentityRef                   `json:"revisedBy"`
+	RevisedDate string                         `json:"revisedDate"`
+	Fields      map[string]WorkItemFieldUpdate `json:"fields"`
+	Relations   *WorkItemRelationUpdates       `json:"relations"`
+}
+
+// WorkItemFieldUpdate holds the old and new value of a changed field.
+type WorkItemFieldUpdate struct {
+	OldValue any `json:"oldValue"`
+	NewValue any `json:"newValue"`
+}
+
+// WorkItemRelationUpdates lists the links added, removed or updated in a revision.
+type WorkItemRelationUpdates struct {
+	Added   []WorkItemRelation `json:"added"`
+	Removed []WorkItemRelation `json:"removed"`
+	Updated []WorkItemRelation `json:"updated"`
+}
+
+// FetchUpdates retrieves all of a work item's updates, oldest first.
+func (c *Client) FetchUpdates(ctx context.Context, parsed *ParsedWorkItem) ([]WorkItemUpdate, error) {
+	const pageSize = 200
+
+	var all []WorkItemUpdate
+	for skip := 0; ; skip += pageSize {
+		var resp WorkItemUpdatesResponse
+		if err := c.api.GetJSON(ctx, c.WorkItemUpdatesURL(parsed, pageSize, skip), &resp); err != nil {
+			return nil, err
+		}
+
+		all = append(all, resp.Value...)
+		if len(resp.Value) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// WIQLResponse represents the WIQL query API response.
+// Flat queries populate WorkItems; link queries (e.g. children of an epic) populate WorkItemRelations.
+type WIQLResponse struct {
+	QueryType         string                 `json:"queryType"`
+	WorkItems         []WorkItemReference    `json:"workItems"`
+	WorkItemRelations []WorkItemLinkRelation `json:"workItemRelations"`
+}
+
+// WorkItemReference is a reference to a work item returned by WIQL.
+type WorkItemReference struct {
+	ID  int    `json:"id"`
+	URL string `json:"url"`
+}
+
+// WorkItemLinkRelation is a source -> target link returned by a WIQL link query.
+type WorkItemLinkRelation struct {
+	Rel    string             `json:"rel"`
+	Source *WorkItemReference `json:"source"`
+	Target *WorkItemReference `json:"target"`
+}
+
+// IDs returns the work item IDs from a WIQL response in result order, without duplicates.
+func (r *WIQLResponse) IDs() []int {
+	seen := make(map[int]bool)
+	var ids []int
+	add := func(ref *WorkItemReference) {
+		if ref == nil || ref.ID == 0 || seen[ref.ID] {
+			return
+		}
+		seen[ref.ID] = true
+		ids = append(ids, ref.ID)
+	}
+	for i := range r.WorkItems {
+		add(&r.WorkItems[i])
+	}
+	for _, rel := range r.WorkItemRelations {
+		add(rel.Source)
+		add(rel.Target)
+	}
+	return ids
+}
+
+type wiqlRequest struct {
+	Query string `json:"query"`
+}
+
+// RunWIQL runs a WIQL query and returns the raw response.
+func (c *Client) RunWIQL(ctx context.Context, org, project, team, query string, top int) (*WIQLResponse, error) {
+	var resp WIQLResponse
+	if err := c.api.SendJSON(ctx, http.MethodPost, c.WIQLURL(org, project, team, top), "", wiqlRequest{Query: query}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// maxBatchSize is the maximum number of IDs accepted by the work items batch API.
+const maxBatchSize = 200
+
+type workItemsBatchRequest struct {
+	IDs    []int    `json:"ids"`
+	Fields []string `json:"fields,omitempty"`
+	Expand string   `json:"$expand,omitempty"`
+	// ErrorPolicy "omit" returns null for IDs that are deleted or not readable instead of
+	// failing the whole batch.
+	ErrorPolicy string `json:"errorPolicy,omitempty"`
+}
+
+type workItemsBatchResponse struct {
+	Value []*WorkItemResponse `json:"value"`
+}
+
+// FetchWorkItemsBatch fetches work items by ID in chunks of up to 200, preserving the order of ids.
+// When fields is empty, all fields and relations are returned. IDs that are deleted or not
+// readable by the user are left out.
+func (c *Client) FetchWorkItemsBatch(ctx context.Context, org, project string, ids []int, fields []string) ([]WorkItemResponse, error) {
+	byID := make(map[int]WorkItemResponse, len(ids))
+	for start := 0; start < len(ids); start += maxBatchSize {
+		end := min(start+maxBatchSize, len(ids))
+		body := workItemsBatchRequest{IDs: ids[start:end], Fields: fields, ErrorPolicy: "omit"}
+		if len(fields) == 0 {
+			body.Expand = "relations"
+		}
+
+		var resp workItemsBatchResponse
+		if err := c.api.SendJSON(ctx, http.MethodPost, c.WorkItemsBatchURL(org, project), "", body, &resp); err != nil {
+			return nil, err
+		}
+		for _, wi := range resp.Value {
+			if wi != nil {
+				byID[wi.ID] = *wi
+			}
+		}
+	}
+
+	items := make([]WorkItemResponse, 0, len(ids))
+	for _, id := range ids {
+		if wi, ok := byID[id]; ok {
+			items = append(items, wi)
+		}
+	}
+	return items, nil
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/krubenok/toolbox/internal/ado"
 	"github.com/krubenok/toolbox/internal/auth"
 )
 
@@ -75,8 +76,9 @@ func TestClientFetchWorkItemAndComments(t *testing.T) {
 	}
 
 	parsed := &ParsedWorkItem{Organization: org, Project: project, ID: id}
-	client := NewClientWithBaseURL(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
-	client.httpClient.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
+	client := NewClient(api)
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
 		if got := r.Header.Get("Authorization"); !strings.HasPrefix(got, "Basic ") && !strings.HasPrefix(got, "Bearer ") {
 			t.Fatalf("missing Authorization header: %q", got)
 		}
@@ -112,7 +114,7 @@ func TestClientFetchWorkItemAndComments(t *testing.T) {
 			Body:       io.NopCloser(strings.NewReader(string(b))),
 			Request:    r,
 		}, nil
-	})
+	}))
 
 	wi, err := client.FetchWorkItem(context.Background(), parsed)
 	if err != nil {
@@ -130,7 +132,7 @@ func TestClientFetchWorkItemAndComments(t *testing.T) {
 		t.Fatalf("len(comments)=%d, want 2", len(comments))
 	}
 
-	simplified := SimplifyWorkItem(parsed, *wi, comments, defaultBaseURL)
+	simplified := SimplifyWorkItem(parsed, *wi, comments, ado.DefaultBaseURL)
 	if simplified.Description != "Hello\nWorld" {
 		t.Fatalf("description=%q, want %q", simplified.Description, "Hello\nWorld")
 	}
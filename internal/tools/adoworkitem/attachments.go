@@ -0,0 +1,251 @@
+package adoworkitem
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/config"
+)
+
+const (
+	// defaultMaxDownloadBytes caps the size of a single downloaded attachment.
+	defaultMaxDownloadBytes = 10 * 1024 * 1024
+	// defaultMaxInlineBytes caps the size of a text attachment inlined into the output.
+	defaultMaxInlineBytes = 32 * 1024
+	// defaultMaxImageBytes caps the size of a single image returned as image content.
+	defaultMaxImageBytes = 1024 * 1024
+	// defaultMaxTotalImageBytes caps the combined size of the images returned for one work item.
+	defaultMaxTotalImageBytes = 4 * 1024 * 1024
+)
+
+// textExtensions are attachment extensions that are inlined as text.
+var textExtensions = map[string]bool{
+	".txt": true, ".log": true, ".json": true, ".md": true, ".csv": true,
+	".xml": true, ".yaml": true, ".yml": true, ".ini": true, ".trace": true,
+}
+
+// imageTypes are the image MIME types returned to MCP clients as image content.
+var imageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// AttachmentImage is a downloaded image attachment.
+type AttachmentImage struct {
+	Name     string
+	MIMEType string
+	Data     []byte
+}
+
+// attachmentLimits is AttachmentConfig with defaults applied.
+type attachmentLimits struct {
+	cacheDir      string
+	maxDownload   int64
+	maxInline     int64
+	maxImage      int64
+	maxTotalImage int64
+}
+
+func (ac *AttachmentConfig) limits() (attachmentLimits, error) {
+	var l attachmentLimits
+	if ac != nil {
+		l = attachmentLimits{
+			cacheDir:      ac.CacheDir,
+			maxDownload:   ac.MaxDownloadBytes,
+			maxInline:     ac.MaxInlineBytes,
+			maxImage:      ac.MaxImageBytes,
+			maxTotalImage: ac.MaxTotalImageBytes,
+		}
+	}
+	if l.maxDownload <= 0 {
+		l.maxDownload = defaultMaxDownloadBytes
+	}
+	if l.maxInline <= 0 {
+		l.maxInline = defaultMaxInlineBytes
+	}
+	if l.maxImage <= 0 {
+		l.maxImage = defaultMaxImageBytes
+	}
+	if l.maxTotalImage <= 0 {
+		l.maxTotalImage = defaultMaxTotalImageBytes
+	}
+	if l.cacheDir == "" {
+		dir, err := config.Dir()
+		if err != nil {
+			return l, err
+		}
+		l.cacheDir = filepath.Join(dir, "cache", "attachments")
+	}
+	return l, nil
+}
+
+// DownloadAttachments downloads attachments into the local cache, reusing files already
+// cached, and fills in LocalPath, inlined text Content, or a Note explaining why an
+// attachment was skipped. Image attachments within the image limits are also returned as
+// AttachmentImage values. Failures for individual attachments are recorded as notes rather
+// than returned.
+func (c *Client) DownloadAttachments(ctx context.Context, attachments []SimplifiedAttachment, cfg *AttachmentConfig) ([]SimplifiedAttachment, []AttachmentImage, error) {
+	limits, err := cfg.limits()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var images []AttachmentImage
+	var imageBytes int64
+	out := make([]SimplifiedAttachment, 0, len(attachments))
+	for _, a := range attachments {
+		data, contentType, err := c.fetchAttachment(ctx, &a, limits)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			a.Note = err.Error()
+			out = append(out, a)
+			continue
+		}
+
+		switch kind := attachmentMIMEType(a.Name, contentType, data); {
+		case imageTypes[kind]:
+			size := int64(len(data))
+			switch {
+			case size > limits.maxImage:
+				a.Note = fmt.Sprintf("image not returned: %d bytes exceeds image limit of %d", size, limits.maxImage)
+			case imageBytes+size > limits.maxTotalImage:
+				a.Note = fmt.Sprintf("image not returned: total image size would exceed limit of %d", limits.maxTotalImage)
+			default:
+				imageBytes += size
+				images = append(images, AttachmentImage{Name: a.Name, MIMEType: kind, Data: data})
+			}
+		case isTextAttachment(a.Name, kind) && utf8.Valid(data):
+			if int64(len(data)) > limits.maxInline {
+				a.Note = fmt.Sprintf("not inlined: %d bytes exceeds inline limit of %d", len(data), limits.maxInline)
+			} else {
+				a.Content = strings.TrimSpace(string(data))
+			}
+		}
+		out = append(out, a)
+	}
+	return out, images, nil
+}
+
+// fetchAttachment returns the attachment's bytes from the cache or downloads and caches
+// them, setting a.LocalPath on success.
+func (c *Client) fetchAttachment(ctx context.Context, a *SimplifiedAttachment, limits attachmentLimits) ([]byte, string, error) {
+	if a.Size > limits.maxDownload {
+		return nil, "", fmt.Errorf("not downloaded: %d bytes exceeds download limit of %d", a.Size, limits.maxDownload)
+	}
+
+	srcURL := a.DownloadURL
+	if srcURL == "" {
+		srcURL = a.URL
+	}
+	id := attachmentID(srcURL)
+	if id == "" {
+		return nil, "", fmt.Errorf("not downloaded: cannot determine attachment id from %q", srcURL)
+	}
+	localPath := filepath.Join(limits.cacheDir, id, attachmentFileName(a.Name, id))
+
+	if info, err := os.Stat(localPath); err == nil && (a.Size == 0 || info.Size() == a.Size) {
+		data, err := os.ReadFile(localPath)
+		if err == nil {
+			c.api.Debugf("Using cached attachment: %s", localPath)
+			a.LocalPath = localPath
+			return data, "", nil
+		}
+	}
+
+	// The download is authenticated, so only fetch from the organization's own server.
+	if !sameHost(srcURL, c.api.BaseURL()) {
+		return nil, "", fmt.Errorf("not downloaded: %s is not on %s", srcURL, c.api.BaseURL())
+	}
+	raw := &ado.RawBody{Limit: limits.maxDownload}
+	if err := c.api.GetJSON(ctx, srcURL, raw); err != nil {
+		return nil, "", fmt.Errorf("download failed: %w", err)
+	}
+	if raw.Truncated {
+		return nil, "", fmt.Errorf("not downloaded: exceeds download limit of %d bytes", limits.maxDownload)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return nil, "", fmt.Errorf("cache attachment: %w", err)
+	}
+	if err := os.WriteFile(localPath, raw.Data, 0644); err != nil {
+		return nil, "", fmt.Errorf("cache attachment: %w", err)
+	}
+	a.LocalPath = localPath
+	return raw.Data, raw.ContentType, nil
+}
+
+// attachmentID returns the attachment GUID, the last path segment of its URL, or "" if
+// that segment cannot be used as a cache directory name.
+func attachmentID(rawURL string) string {
+	var id string
+	if strings.HasPrefix(rawURL, "vstfs:///") {
+		id = path.Base(rawURL)
+	} else {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return ""
+		}
+		id = path.Base(u.Path)
+	}
+	switch id {
+	case "", ".", "..", "/":
+		return ""
+	}
+	return id
+}
+
+// sameHost reports whether rawURL has the same scheme and host as baseURL, so the
+// client's credentials may be sent to it.
+func sameHost(rawURL, baseURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
+
+// attachmentFileName returns a name that is safe to use as a single path element.
+func attachmentFileName(name, fallback string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "" || name == "." || name == ".." || name == "/" {
+		return fallback
+	}
+	return name
+}
+
+// attachmentMIMEType determines the MIME type from the file extension, then the response
+// Content-Type, then by sniffing the content.
+func attachmentMIMEType(name, contentType string, data []byte) string {
+	candidates := []string{mime.TypeByExtension(strings.ToLower(filepath.Ext(name))), contentType}
+	for _, ct := range candidates {
+		if mt, _, err := mime.ParseMediaType(ct); err == nil && mt != "application/octet-stream" {
+			return mt
+		}
+	}
+	mt, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	return mt
+}
+
+func isTextAttachment(name, mimeType string) bool {
+	if textExtensions[strings.ToLower(filepath.Ext(name))] {
+		return true
+	}
+	return strings.HasPrefix(mimeType, "text/") || mimeType == "application/json" || mimeType == "application/xml"
+}
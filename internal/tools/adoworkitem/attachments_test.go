@@ -0,0 +1,160 @@
+package adoworkitem
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestDownloadAttachments(t *testing.T) {
+	t.Parallel()
+
+	pngData := "\x89PNG\r\n\x1a\n" + strings.Repeat("\x00", 16)
+	bodies := map[string]string{
+		"11111111-0000-0000-0000-000000000000": `{"error": "boom"}`,
+		"22222222-0000-0000-0000-000000000000": pngData,
+		"33333333-0000-0000-0000-000000000000": strings.Repeat("x", 100),
+		"44444444-0000-0000-0000-000000000000": "\x00\x01binary",
+		"66666666-0000-0000-0000-000000000000": pngData + strings.Repeat("\x00", 64),
+		"77777777-0000-0000-0000-000000000000": pngData,
+	}
+
+	var requests atomic.Int32
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		body, ok := bodies[id]
+		if !ok {
+			t.Fatalf("unexpected request: %s", r.URL)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/octet-stream"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}))
+	client := NewClient(api)
+
+	base := "https://dev.azure.com/org/project/_apis/wit/attachments/"
+	attachments := []SimplifiedAttachment{
+		{Name: "error.json", DownloadURL: base + "11111111-0000-0000-0000-000000000000"},
+		{Name: "screenshot.png", DownloadURL: base + "22222222-0000-0000-0000-000000000000"},
+		{Name: "big.log", DownloadURL: base + "33333333-0000-0000-0000-000000000000"},
+		{Name: "dump.bin", DownloadURL: base + "44444444-0000-0000-0000-000000000000"},
+		{Name: "huge.zip", DownloadURL: base + "55555555-0000-0000-0000-000000000000", Size: 1 << 30},
+		{Name: "large.png", DownloadURL: base + "66666666-0000-0000-0000-000000000000"},
+		{Name: "second.png", DownloadURL: base + "77777777-0000-0000-0000-000000000000"},
+		{Name: "elsewhere.txt", DownloadURL: "https://attacker.example/org/project/_apis/wit/attachments/88888888-0000-0000-0000-000000000000"},
+	}
+	cfg := &AttachmentConfig{CacheDir: t.TempDir(), MaxDownloadBytes: 1024, MaxInlineBytes: 64, MaxImageBytes: 32, MaxTotalImageBytes: 40}
+
+	got, images, err := client.DownloadAttachments(context.Background(), attachments, cfg)
+	if err != nil {
+		t.Fatalf("DownloadAttachments: %v", err)
+	}
+	if len(got) != len(attachments) {
+		t.Fatalf("got %d attachments, want %d", len(got), len(attachments))
+	}
+
+	if got[0].Content != `{"error": "boom"}` {
+		t.Fatalf("json content = %q", got[0].Content)
+	}
+	wantPath := filepath.Join(cfg.CacheDir, "11111111-0000-0000-0000-000000000000", "error.json")
+	if got[0].LocalPath != wantPath {
+		t.Fatalf("LocalPath = %q, want %q", got[0].LocalPath, wantPath)
+	}
+	if data, err := os.ReadFile(wantPath); err != nil || string(data) != `{"error": "boom"}` {
+		t.Fatalf("cached file = %q, %v", data, err)
+	}
+
+	if len(images) != 1 || images[0].Name != "screenshot.png" || images[0].MIMEType != "image/png" || string(images[0].Data) != pngData {
+		t.Fatalf("images = %+v", images)
+	}
+	if got[1].Content != "" {
+		t.Fatalf("image should not be inlined, got %q", got[1].Content)
+	}
+
+	if got[2].Content != "" || !strings.Contains(got[2].Note, "inline limit") || got[2].LocalPath == "" {
+		t.Fatalf("big log = %+v", got[2])
+	}
+	if got[3].Content != "" || got[3].Note != "" || got[3].LocalPath == "" {
+		t.Fatalf("binary = %+v", got[3])
+	}
+	if got[4].LocalPath != "" || !strings.Contains(got[4].Note, "download limit") {
+		t.Fatalf("huge = %+v", got[4])
+	}
+	if got[5].LocalPath == "" || !strings.Contains(got[5].Note, "image limit") {
+		t.Fatalf("large image = %+v", got[5])
+	}
+	if got[6].LocalPath == "" || !strings.Contains(got[6].Note, "total image size") {
+		t.Fatalf("second image = %+v", got[6])
+	}
+	if got[7].LocalPath != "" || !strings.Contains(got[7].Note, "is not on https://dev.azure.com") {
+		t.Fatalf("foreign host = %+v", got[7])
+	}
+
+	// A second run is served from the cache.
+	before := requests.Load()
+	if _, _, err := client.DownloadAttachments(context.Background(), attachments, cfg); err != nil {
+		t.Fatalf("DownloadAttachments (cached): %v", err)
+	}
+	if after := requests.Load(); after != before {
+		t.Fatalf("made %d requests on cached run, want 0", after-before)
+	}
+}
+
+func TestAttachmentFileName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"report.txt", "report.txt"},
+		{"../../etc/passwd", "passwd"},
+		{`..\..\evil.log`, "evil.log"},
+		{"", "fallback"},
+		{"..", "fallback"},
+	}
+	for _, tt := range tests {
+		if got := attachmentFileName(tt.in, "fallback"); got != tt.want {
+			t.Fatalf("attachmentFileName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAttachmentID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://dev.azure.com/org/project/_apis/wit/attachments/1234-abcd", "1234-abcd"},
+		{"https://dev.azure.com/org/project/_apis/wit/attachments/1234-abcd?fileName=a.png", "1234-abcd"},
+		{"vstfs:///Attachment/1234-abcd", "1234-abcd"},
+		{"https://dev.azure.com/org/project/_apis/wit/attachments/..", ""},
+		{"https://dev.azure.com/org/project/_apis/wit/attachments/.", ""},
+		{"https://dev.azure.com/..", ""},
+		{"https://dev.azure.com/", ""},
+		{"https://dev.azure.com", ""},
+		{"vstfs:///..", ""},
+		{"::bad", ""},
+	}
+	for _, tt := range tests {
+		if got := attachmentID(tt.in); got != tt.want {
+			t.Errorf("attachmentID(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package adoworkitem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// reMention matches "@<unique name>" and bare "@user@domain" mentions. The first group
+// is the character before a bare mention (so email addresses in text are not mentions).
+var reMention = regexp.MustCompile(`@<([^<>\s][^<>]*)>|(^|[\s(\[])@([A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)+)`)
+
+// AddCommentOptions configures posting a comment to a work item's discussion.
+type AddCommentOptions struct {
+	Ctx         context.Context
+	WorkItemURL string
+	Message     string // Markdown; mentions are written as @<unique name> or @user@domain.com
+	OutputJSON  bool
+	Debug       bool
+	DebugLog    func(string)
+}
+
+// AddCommentResult contains the comment created on a work item.
+type AddCommentResult struct {
+	WorkItemID int               `json:"workItemId"`
+	Comment    SimplifiedComment `json:"comment"`
+	Output     string            `json:"-"` // Formatted output (toon or JSON)
+}
+
+// AddComment posts a markdown comment to a work item, resolving @mentions to identities.
+func AddComment(opts AddCommentOptions) (*AddCommentResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	message := strings.TrimSpace(opts.Message)
+	if message == "" {
+		return nil, errors.New("message is required")
+	}
+
+	parsed, err := ParseWorkItemURL(opts.WorkItemURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig(parsed.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newRunClient(parsed.BaseURL, parsed.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	text, err := renderComment(message, func(name string) (Identity, error) {
+		return client.resolveMention(ctx, parsed.Organization, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := client.PostComment(ctx, parsed, text)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AddCommentResult{
+		WorkItemID: parsed.ID,
+		Comment:    SimplifyComment(*created),
+	}
+
+	toonValue := map[string]any{
+		"workItemId": result.WorkItemID,
+		"comment":    CommentToMap(result.Comment, cfg.Output),
+	}
+	result.Output, err = marshalOutput(result, toonValue, opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// renderComment converts a markdown comment to HTML, replacing each mention with the
+// anchor Azure DevOps uses for @mentions so the mentioned user is notified.
+func renderComment(message string, resolve func(name string) (Identity, error)) (string, error) {
+	var mentions []string
+	var resolveErr error
+	resolved := make(map[string]int)
+
+	message = reMention.ReplaceAllStringFunc(message, func(m string) string {
+		sub := reMention.FindStringSubmatch(m)
+		prefix, name := sub[2], sub[1]
+		if name == "" {
+			name = sub[3]
+		}
+		name = strings.TrimSpace(name)
+
+		idx, ok := resolved[strings.ToLower(name)]
+		if !ok {
+			identity, err := resolve(name)
+			if err != nil {
+				if resolveErr == nil {
+					resolveErr = err
+				}
+				return m
+			}
+			mentions = append(mentions, fmt.Sprintf(`<a href="#" data-vss-mention="version:2.0,%s">@%s</a>`,
+				html.EscapeString(identity.ID), html.EscapeString(identity.DisplayName())))
+			idx = len(mentions) - 1
+			resolved[strings.ToLower(name)] = idx
+		}
+		return prefix + "\x01" + strconv.Itoa(idx) + "\x01"
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	out := markdownToHTML(message)
+	for i, anchor := range mentions {
+		out = strings.ReplaceAll(out, "\x01"+strconv.Itoa(i)+"\x01", anchor)
+	}
+	return out, nil
+}
+
+// resolveMention looks up the single identity matching a unique name (usually an email).
+func (c *Client) resolveMention(ctx context.Context, org, name string) (Identity, error) {
+	identities, err := c.FindIdentities(ctx, org, name)
+	if err != nil {
+		return Identity{}, fmt.Errorf("resolve mention @%s: %w", name, err)
+	}
+	switch len(identities) {
+	case 0:
+		return Identity{}, fmt.Errorf("resolve mention @%s: no matching user", name)
+	case 1:
+		c.api.Debugf("Resolved mention @%s to %s", name, identities[0].ID)
+		return identities[0], nil
+	default:
+		return Identity{}, fmt.Errorf("resolve mention @%s: %d users match; use a unique name", name, len(identities))
+	}
+}
@@ -0,0 +1,94 @@
+package adoworkitem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestRenderComment(t *testing.T) {
+	t.Parallel()
+
+	identities := map[string]Identity{
+		"jane@example.com": {ID: "11111111-aaaa", ProviderDisplayName: "Jane Doe"},
+		"domain\\john":     {ID: "22222222-bbbb", ProviderDisplayName: "John Roe", CustomDisplayName: "Johnny"},
+	}
+	var lookups []string
+	resolve := func(name string) (Identity, error) {
+		lookups = append(lookups, name)
+		if id, ok := identities[strings.ToLower(name)]; ok {
+			return id, nil
+		}
+		return Identity{}, errors.New("no matching user")
+	}
+
+	got, err := renderComment("Thanks @jane@example.com and @<DOMAIN\\john>, see **PR**.\n\nMail jane@example.com or @jane@example.com again.", resolve)
+	if err != nil {
+		t.Fatalf("renderComment: %v", err)
+	}
+	jane := `<a href="#" data-vss-mention="version:2.0,11111111-aaaa">@Jane Doe</a>`
+	john := `<a href="#" data-vss-mention="version:2.0,22222222-bbbb">@Johnny</a>`
+	want := "<p>Thanks " + jane + " and " + john + ", see <strong>PR</strong>.</p><p>Mail jane@example.com or " + jane + " again.</p>"
+	if got != want {
+		t.Fatalf("got  %q\nwant %q", got, want)
+	}
+	if len(lookups) != 2 {
+		t.Fatalf("lookups = %v, want each mention resolved once", lookups)
+	}
+
+	if _, err := renderComment("hi @<nobody>", resolve); err == nil {
+		t.Fatal("expected error for unresolved mention")
+	}
+}
+
+func TestClientPostCommentAndFindIdentities(t *testing.T) {
+	t.Parallel()
+
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		var body string
+		switch {
+		case r.Method == http.MethodPost && r.URL.Host == "dev.azure.com" && r.URL.Path == "/org/project/_apis/wit/workItems/5/comments":
+			var req newWorkItemComment
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Text != "<p>hi</p>" {
+				t.Fatalf("body = %+v, %v", req, err)
+			}
+			body = `{"id":9,"text":"<p>hi</p>","createdDate":"2025-01-01T00:00:00Z","createdBy":{"displayName":"Me"}}`
+		case r.Method == http.MethodGet && r.URL.Host == "vssps.dev.azure.com" && r.URL.Path == "/org/_apis/identities":
+			if got := r.URL.Query().Get("filterValue"); got != "jane@example.com" {
+				t.Fatalf("filterValue = %q", got)
+			}
+			body = `{"count":1,"value":[{"id":"abc","providerDisplayName":"Jane Doe"}]}`
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}))
+	client := NewClient(api)
+
+	created, err := client.PostComment(context.Background(), &ParsedWorkItem{Organization: "org", Project: "project", ID: 5}, "<p>hi</p>")
+	if err != nil {
+		t.Fatalf("PostComment: %v", err)
+	}
+	if sc := SimplifyComment(*created); sc.ID != 9 || sc.Author != "Me" || sc.Text != "hi" {
+		t.Fatalf("SimplifyComment = %+v", sc)
+	}
+
+	identity, err := client.resolveMention(context.Background(), "org", "jane@example.com")
+	if err != nil || identity.ID != "abc" || identity.DisplayName() != "Jane Doe" {
+		t.Fatalf("resolveMention = %+v, %v", identity, err)
+	}
+}
@@ -8,6 +8,10 @@ import (
 
 const configFile = "ado-work-item.json"
 
+// AttachmentDownloadKey is the config section that is only read from ~/.toolbox and
+// profiles, never from a repository.
+const AttachmentDownloadKey = "attachmentDownload"
+
 // FieldMode specifies when a field should be included in output.
 type FieldMode string
 
@@ -19,7 +23,27 @@ const (
 
 // Config holds all configuration for the ado-work-item tool.
 type Config struct {
+	// Fields lists additional field reference names to include in output
+	// (e.g. "System.AreaPath", "Microsoft.VSTS.Common.Priority", "Custom.Team").
+	Fields []string      `json:"fields,omitempty"`
 	Output *OutputConfig `json:"output,omitempty"`
+	// AttachmentDownload controls where and how much is downloaded when attachments are fetched.
+	AttachmentDownload *AttachmentConfig `json:"attachmentDownload,omitempty"`
+}
+
+// AttachmentConfig controls attachment downloads. Zero values fall back to defaults.
+type AttachmentConfig struct {
+	// CacheDir is where attachments are stored (default ~/.toolbox/cache/attachments).
+	CacheDir string `json:"cacheDir,omitempty"`
+	// MaxDownloadBytes skips attachments larger than this (default 10 MiB).
+	MaxDownloadBytes int64 `json:"maxDownloadBytes,omitempty"`
+	// MaxInlineBytes caps the size of text attachments inlined into the output (default 32 KiB).
+	MaxInlineBytes int64 `json:"maxInlineBytes,omitempty"`
+	// MaxImageBytes caps the size of an image returned as image content (default 1 MiB).
+	MaxImageBytes int64 `json:"maxImageBytes,omitempty"`
+	// MaxTotalImageBytes caps the combined size of the images returned for one work item
+	// (default 4 MiB).
+	MaxTotalImageBytes int64 `json:"maxTotalImageBytes,omitempty"`
 }
 
 // OutputConfig controls which fields are included in output.
@@ -35,12 +59,24 @@ type OutputConfig struct {
 	State       FieldMode `json:"state,omitempty"`
 	AssignedTo  FieldMode `json:"assignedTo,omitempty"`
 	Description FieldMode `json:"description,omitempty"`
+	Fields      FieldMode `json:"fields,omitempty"`
 
 	// Sections
 	Discussion  FieldMode `json:"discussion,omitempty"`
 	Children    FieldMode `json:"children,omitempty"`
+	Links       FieldMode `json:"links,omitempty"`
 	Attachments FieldMode `json:"attachments,omitempty"`
 
+	// Link groups (within links)
+	Parent       FieldMode `json:"parent,omitempty"`
+	Related      FieldMode `json:"related,omitempty"`
+	Predecessors FieldMode `json:"predecessors,omitempty"`
+	Successors   FieldMode `json:"successors,omitempty"`
+	PullRequests FieldMode `json:"pullRequests,omitempty"`
+	Commits      FieldMode `json:"commits,omitempty"`
+	Builds       FieldMode `json:"builds,omitempty"`
+	OtherLinks   FieldMode `json:"otherLinks,omitempty"`
+
 	// Discussion fields
 	CommentID       FieldMode `json:"commentId,omitempty"`
 	CommentAuthor   FieldMode `json:"commentAuthor,omitempty"`
@@ -53,10 +89,33 @@ type OutputConfig struct {
 	ChildURL   FieldMode `json:"childUrl,omitempty"`
 	ChildUIURL FieldMode `json:"childUiUrl,omitempty"`
 
+	// Resolved child fields (only populated with a child depth)
+	ChildTitle      FieldMode `json:"childTitle,omitempty"`
+	ChildType       FieldMode `json:"childType,omitempty"`
+	ChildState      FieldMode `json:"childState,omitempty"`
+	ChildAssignedTo FieldMode `json:"childAssignedTo,omitempty"`
+
+	// Link fields
+	LinkID      FieldMode `json:"linkId,omitempty"`
+	LinkURL     FieldMode `json:"linkUrl,omitempty"`
+	LinkType    FieldMode `json:"linkType,omitempty"`
+	LinkComment FieldMode `json:"linkComment,omitempty"`
+
 	// Attachment fields
 	AttachmentName        FieldMode `json:"attachmentName,omitempty"`
 	AttachmentURL         FieldMode `json:"attachmentUrl,omitempty"`
 	AttachmentDownloadURL FieldMode `json:"attachmentDownloadUrl,omitempty"`
+	AttachmentSize        FieldMode `json:"attachmentSize,omitempty"`
+	AttachmentPath        FieldMode `json:"attachmentPath,omitempty"`
+	AttachmentContent     FieldMode `json:"attachmentContent,omitempty"`
+	AttachmentNote        FieldMode `json:"attachmentNote,omitempty"`
+
+	// History fields (ado-work-item-history; id, uiUrl and rev are shared with work items)
+	By           FieldMode `json:"by,omitempty"`
+	Date         FieldMode `json:"date,omitempty"`
+	Changes      FieldMode `json:"changes,omitempty"`
+	LinksAdded   FieldMode `json:"linksAdded,omitempty"`
+	LinksRemoved FieldMode `json:"linksRemoved,omitempty"`
 }
 
 // DefaultOutputConfig returns the default output config.
@@ -72,11 +131,22 @@ func DefaultOutputConfig() *OutputConfig {
 		State:       FieldModeNotEmpty,
 		AssignedTo:  FieldModeNotEmpty,
 		Description: FieldModeAlways,
+		Fields:      FieldModeNotEmpty,
 
 		Discussion:  FieldModeAlways,
 		Children:    FieldModeAlways,
+		Links:       FieldModeAlways,
 		Attachments: FieldModeAlways,
 
+		Parent:       FieldModeNotEmpty,
+		Related:      FieldModeNotEmpty,
+		Predecessors: FieldModeNotEmpty,
+		Successors:   FieldModeNotEmpty,
+		PullRequests: FieldModeNotEmpty,
+		Commits:      FieldModeNotEmpty,
+		Builds:       FieldModeNotEmpty,
+		OtherLinks:   FieldModeNotEmpty,
+
 		CommentID:       FieldModeNotEmpty,
 		CommentAuthor:   FieldModeNotEmpty,
 		CommentCreated:  FieldModeNotEmpty,
@@ -87,9 +157,29 @@ func DefaultOutputConfig() *OutputConfig {
 		ChildURL:   FieldModeNotEmpty,
 		ChildUIURL: FieldModeNotEmpty,
 
+		ChildTitle:      FieldModeNotEmpty,
+		ChildType:       FieldModeNotEmpty,
+		ChildState:      FieldModeNotEmpty,
+		ChildAssignedTo: FieldModeNotEmpty,
+
+		LinkID:      FieldModeNotEmpty,
+		LinkURL:     FieldModeNotEmpty,
+		LinkType:    FieldModeNotEmpty,
+		LinkComment: FieldModeNotEmpty,
+
 		AttachmentName:        FieldModeNotEmpty,
 		AttachmentURL:         FieldModeNotEmpty,
 		AttachmentDownloadURL: FieldModeNotEmpty,
+		AttachmentSize:        FieldModeNotEmpty,
+		AttachmentPath:        FieldModeNotEmpty,
+		AttachmentContent:     FieldModeNotEmpty,
+		AttachmentNote:        FieldModeNotEmpty,
+
+		By:           FieldModeNotEmpty,
+		Date:         FieldModeNotEmpty,
+		Changes:      FieldModeNotEmpty,
+		LinksAdded:   FieldModeNotEmpty,
+		LinksRemoved: FieldModeNotEmpty,
 	}
 }
 
@@ -119,12 +209,32 @@ func (oc *OutputConfig) GetFieldMode(field string) FieldMode {
 		mode = oc.AssignedTo
 	case "description":
 		mode = oc.Description
+	case "fields":
+		mode = oc.Fields
 	case "discussion":
 		mode = oc.Discussion
 	case "children":
 		mode = oc.Children
+	case "links":
+		mode = oc.Links
 	case "attachments":
 		mode = oc.Attachments
+	case "parent":
+		mode = oc.Parent
+	case "related":
+		mode = oc.Related
+	case "predecessors":
+		mode = oc.Predecessors
+	case "successors":
+		mode = oc.Successors
+	case "pullRequests":
+		mode = oc.PullRequests
+	case "commits":
+		mode = oc.Commits
+	case "builds":
+		mode = oc.Builds
+	case "otherLinks":
+		mode = oc.OtherLinks
 	case "commentId":
 		mode = oc.CommentID
 	case "commentAuthor":
@@ -141,12 +251,46 @@ func (oc *OutputConfig) GetFieldMode(field string) FieldMode {
 		mode = oc.ChildURL
 	case "childUiUrl":
 		mode = oc.ChildUIURL
+	case "childTitle":
+		mode = oc.ChildTitle
+	case "childType":
+		mode = oc.ChildType
+	case "childState":
+		mode = oc.ChildState
+	case "childAssignedTo":
+		mode = oc.ChildAssignedTo
+	case "linkId":
+		mode = oc.LinkID
+	case "linkUrl":
+		mode = oc.LinkURL
+	case "linkType":
+		mode = oc.LinkType
+	case "linkComment":
+		mode = oc.LinkComment
 	case "attachmentName":
 		mode = oc.AttachmentName
 	case "attachmentUrl":
 		mode = oc.AttachmentURL
 	case "attachmentDownloadUrl":
 		mode = oc.AttachmentDownloadURL
+	case "attachmentSize":
+		mode = oc.AttachmentSize
+	case "attachmentPath":
+		mode = oc.AttachmentPath
+	case "attachmentContent":
+		mode = oc.AttachmentContent
+	case "attachmentNote":
+		mode = oc.AttachmentNote
+	case "by":
+		mode = oc.By
+	case "date":
+		mode = oc.Date
+	case "changes":
+		mode = oc.Changes
+	case "linksAdded":
+		mode = oc.LinksAdded
+	case "linksRemoved":
+		mode = oc.LinksRemoved
 	}
 
 	if mode == "" {
@@ -155,11 +299,15 @@ func (oc *OutputConfig) GetFieldMode(field string) FieldMode {
 	return mode
 }
 
-// LoadConfig loads the ado-work-item config from ~/.toolbox/ado-work-item.json.
-// Falls back to defaults if file doesn't exist.
-func LoadConfig() (*Config, error) {
+// LoadConfig loads the ado-work-item config from ~/.toolbox/ado-work-item.json and the
+// repository's .toolbox/ado-work-item.json, layered over the "ado-work-item" section of the
+// profile for org (see config.LoadForOrg). The attachmentDownload section is ignored in the
+// repository file. Falls back to defaults if none exists.
+func LoadConfig(org string) (*Config, error) {
 	var cfg Config
-	err := config.Load(configFile, &cfg)
+	// Attachment downloads are authenticated and written to disk, so a checked-out
+	// repository must not choose where they go.
+	err := config.LoadForOrgUserKeys(org, configFile, &cfg, AttachmentDownloadKey)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &Config{Output: DefaultOutputConfig()}, nil
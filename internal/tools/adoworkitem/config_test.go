@@ -0,0 +1,41 @@
+package adoworkitem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/config"
+)
+
+func TestLoadConfigIgnoresRepoAttachmentDownload(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(config.ProfileEnv, "")
+
+	write := func(dir, content string) {
+		t.Helper()
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, configFile), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(home, ".toolbox"), `{"attachmentDownload": {"maxInlineBytes": 100}}`)
+	repo := t.TempDir()
+	write(filepath.Join(repo, ".toolbox"), `{"fields": ["Custom.Team"], "attachmentDownload": {"cacheDir": "/tmp/elsewhere"}}`)
+	t.Chdir(repo)
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig: %v", err)
+	}
+	if len(cfg.Fields) != 1 || cfg.Fields[0] != "Custom.Team" {
+		t.Fatalf("fields = %v, want repository value", cfg.Fields)
+	}
+	if a := cfg.AttachmentDownload; a == nil || a.CacheDir != "" || a.MaxInlineBytes != 100 {
+		t.Fatalf("attachmentDownload = %+v, want user settings only", a)
+	}
+}
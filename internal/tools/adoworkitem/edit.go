@@ -0,0 +1,239 @@
+package adoworkitem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/krubenok/toolbox/internal/ado"
+)
+
+// jsonPatchContentType is the content type the work item API requires for create and update.
+const jsonPatchContentType = "application/json-patch+json"
+
+// PatchOperation is a single JSON Patch operation in a work item create or update request.
+type PatchOperation struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+// WorkItemChanges lists the fields to set on a work item. Empty values are left unchanged.
+type WorkItemChanges struct {
+	Title         string
+	Description   string // Markdown, converted to HTML
+	State         string
+	AssignedTo    string // Display name, email or unique name
+	AreaPath      string
+	IterationPath string
+	Tags          []string // Replaces all existing tags
+	Parent        string   // Parent work item ID or URL
+}
+
+// CreateOptions configures creating a work item.
+type CreateOptions struct {
+	Ctx        context.Context
+	ProjectURL string // Any ADO URL identifying the organization and project
+	Type       string // Work item type, e.g. Bug, Task, User Story
+	Changes    WorkItemChanges
+
+	DryRun     bool // Print the patch document instead of sending it
+	OutputJSON bool
+	Debug      bool
+	DebugLog   func(string)
+}
+
+// UpdateOptions configures updating an existing work item.
+type UpdateOptions struct {
+	Ctx         context.Context
+	WorkItemURL string
+	Changes     WorkItemChanges
+
+	DryRun     bool // Print the patch document instead of sending it
+	OutputJSON bool
+	Debug      bool
+	DebugLog   func(string)
+}
+
+// EditResult contains the outcome of a create or update.
+type EditResult struct {
+	Patch    []PatchOperation    // The patch document that was (or, with DryRun, would be) sent
+	WorkItem *SimplifiedWorkItem // The created or updated work item (nil with DryRun)
+	Output   string
+}
+
+// Create creates a work item from the given changes.
+func Create(opts CreateOptions) (*EditResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if strings.TrimSpace(opts.Type) == "" {
+		return nil, errors.New("work item type is required")
+	}
+	if strings.TrimSpace(opts.Changes.Title) == "" {
+		return nil, errors.New("title is required")
+	}
+
+	project, err := ParseProjectURL(opts.ProjectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := BuildPatch(opts.Changes, project.BaseURL, project.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if opts.DryRun {
+		return dryRunResult(patch)
+	}
+
+	cfg, err := LoadConfig(project.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newRunClient(project.BaseURL, project.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	wi, err := client.CreateWorkItem(ctx, project.Organization, project.Project, strings.TrimSpace(opts.Type), patch)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := &ParsedWorkItem{BaseURL: project.BaseURL, Organization: project.Organization, Project: project.Project, ID: wi.ID}
+	return editResult(patch, parsed, *wi, client.BaseURL(), cfg, opts.OutputJSON, opts.Debug, opts.DebugLog)
+}
+
+// Update applies the given changes to an existing work item.
+func Update(opts UpdateOptions) (*EditResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	parsed, err := ParseWorkItemURL(opts.WorkItemURL)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := BuildPatch(opts.Changes, parsed.BaseURL, parsed.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if len(patch) == 0 {
+		return nil, errors.New("no changes specified")
+	}
+	if opts.DryRun {
+		return dryRunResult(patch)
+	}
+
+	cfg, err := LoadConfig(parsed.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newRunClient(parsed.BaseURL, parsed.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	wi, err := client.UpdateWorkItem(ctx, parsed, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	return editResult(patch, parsed, *wi, client.BaseURL(), cfg, opts.OutputJSON, opts.Debug, opts.DebugLog)
+}
+
+// BuildPatch converts changes into a JSON Patch document. The parent link, if any,
+// is added as a Hierarchy-Reverse relation to a work item in the same organization.
+func BuildPatch(changes WorkItemChanges, baseURL, org string) ([]PatchOperation, error) {
+	var patch []PatchOperation
+	set := func(field, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			patch = append(patch, PatchOperation{Op: "add", Path: "/fields/" + field, Value: value})
+		}
+	}
+
+	set("System.Title", changes.Title)
+	if strings.TrimSpace(changes.Description) != "" {
+		set("System.Description", markdownToHTML(changes.Description))
+	}
+	set("System.State", changes.State)
+	set("System.AssignedTo", changes.AssignedTo)
+	set("System.AreaPath", changes.AreaPath)
+	set("System.IterationPath", changes.IterationPath)
+	if changes.Tags != nil {
+		var tags []string
+		for _, t := range changes.Tags {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, t)
+			}
+		}
+		patch = append(patch, PatchOperation{Op: "add", Path: "/fields/System.Tags", Value: strings.Join(tags, "; ")})
+	}
+
+	if parent := strings.TrimSpace(changes.Parent); parent != "" {
+		id, err := parseWorkItemRef(parent)
+		if err != nil {
+			return nil, fmt.Errorf("parent: %w", err)
+		}
+		patch = append(patch, PatchOperation{
+			Op:   "add",
+			Path: "/relations/-",
+			Value: map[string]any{
+				"rel": relParent,
+				"url": ado.NormalizeBaseURL(baseURL) + "/" + url.PathEscape(org) + "/_apis/wit/workItems/" + strconv.Itoa(id),
+			},
+		})
+	}
+
+	return patch, nil
+}
+
+// parseWorkItemRef accepts a work item ID ("123" or "#123") or a work item URL.
+func parseWorkItemRef(ref string) (int, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		if id <= 0 {
+			return 0, fmt.Errorf("invalid work item id: %s", ref)
+		}
+		return id, nil
+	}
+	parsed, err := ParseWorkItemURL(ref)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.ID, nil
+}
+
+// dryRunResult renders the patch document as indented JSON, leaving HTML unescaped
+// so descriptions stay readable.
+func dryRunResult(patch []PatchOperation) (*EditResult, error) {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(patch); err != nil {
+		return nil, err
+	}
+	return &EditResult{Patch: patch, Output: strings.TrimRight(b.String(), "\n")}, nil
+}
+
+func editResult(patch []PatchOperation, parsed *ParsedWorkItem, wi WorkItemResponse, baseURL string, cfg *Config, outputJSON, debug bool, debugLog func(string)) (*EditResult, error) {
+	simplified := SimplifyWorkItem(parsed, wi, nil, baseURL)
+	simplified.Discussion = []SimplifiedComment{}
+
+	output, err := marshalOutput(simplified, WorkItemToMap(simplified, cfg.Output), outputJSON, debug, debugLog)
+	if err != nil {
+		return nil, err
+	}
+	return &EditResult{Patch: patch, WorkItem: &simplified, Output: output}, nil
+}
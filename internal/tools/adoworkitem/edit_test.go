@@ -0,0 +1,136 @@
+package adoworkitem
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestBuildPatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all fields", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := BuildPatch(WorkItemChanges{
+			Title:         " Crash on save ",
+			Description:   "Steps:\n- open",
+			State:         "Active",
+			AssignedTo:    "jane@example.com",
+			AreaPath:      `Project\Team`,
+			IterationPath: `Project\Sprint 1`,
+			Tags:          []string{"regression", " ", "ui"},
+			Parent:        "https://dev.azure.com/org/project/_workitems/edit/77",
+		}, "https://dev.azure.com/", "org")
+		if err != nil {
+			t.Fatalf("BuildPatch: %v", err)
+		}
+
+		want := []PatchOperation{
+			{Op: "add", Path: "/fields/System.Title", Value: "Crash on save"},
+			{Op: "add", Path: "/fields/System.Description", Value: "<p>Steps:</p><ul><li>open</li></ul>"},
+			{Op: "add", Path: "/fields/System.State", Value: "Active"},
+			{Op: "add", Path: "/fields/System.AssignedTo", Value: "jane@example.com"},
+			{Op: "add", Path: "/fields/System.AreaPath", Value: `Project\Team`},
+			{Op: "add", Path: "/fields/System.IterationPath", Value: `Project\Sprint 1`},
+			{Op: "add", Path: "/fields/System.Tags", Value: "regression; ui"},
+			{Op: "add", Path: "/relations/-", Value: map[string]any{
+				"rel": "System.LinkTypes.Hierarchy-Reverse",
+				"url": "https://dev.azure.com/org/_apis/wit/workItems/77",
+			}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got  %#v\nwant %#v", got, want)
+		}
+	})
+
+	t.Run("empty changes", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := BuildPatch(WorkItemChanges{}, "", "org")
+		if err != nil || len(got) != 0 {
+			t.Fatalf("got %v, %v; want empty patch", got, err)
+		}
+	})
+
+	t.Run("invalid parent", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := BuildPatch(WorkItemChanges{Parent: "not a work item"}, "", "org"); err == nil {
+			t.Fatal("expected error for invalid parent")
+		}
+	})
+}
+
+func TestParseWorkItemRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "123", want: 123},
+		{in: "#45", want: 45},
+		{in: "https://org.visualstudio.com/project/_workitems/edit/9", want: 9},
+		{in: "0", wantErr: true},
+		{in: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseWorkItemRef(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Fatalf("parseWorkItemRef(%q) = %d, %v; want %d, err=%v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestClientCreateAndUpdateWorkItem(t *testing.T) {
+	t.Parallel()
+
+	patch := []PatchOperation{{Op: "add", Path: "/fields/System.Title", Value: "New bug"}}
+
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if got := r.Header.Get("Content-Type"); got != "application/json-patch+json" {
+			t.Fatalf("Content-Type = %q", got)
+		}
+		var body []PatchOperation
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body) != 1 || body[0].Path != "/fields/System.Title" {
+			t.Fatalf("body = %+v, %v", body, err)
+		}
+		if r.URL.Query().Get("$expand") != "relations" {
+			t.Fatalf("missing $expand=relations: %s", r.URL)
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/org/project/_apis/wit/workitems/$User%20Story":
+		case r.Method == http.MethodPatch && r.URL.Path == "/org/project/_apis/wit/workitems/42":
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.EscapedPath())
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"id":42,"rev":1,"fields":{"System.Title":"New bug"}}`)),
+			Request:    r,
+		}, nil
+	}))
+	client := NewClient(api)
+
+	created, err := client.CreateWorkItem(context.Background(), "org", "project", "User Story", patch)
+	if err != nil || created.ID != 42 {
+		t.Fatalf("CreateWorkItem = %+v, %v", created, err)
+	}
+	updated, err := client.UpdateWorkItem(context.Background(), &ParsedWorkItem{Organization: "org", Project: "project", ID: 42}, patch)
+	if err != nil || updated.ID != 42 {
+		t.Fatalf("UpdateWorkItem = %+v, %v", updated, err)
+	}
+}
@@ -0,0 +1,71 @@
+package adoworkitem
+
+import (
+	"strings"
+)
+
+// mergeFieldNames combines field reference names from config and options,
+// dropping blanks and case-insensitive duplicates while preserving order.
+func mergeFieldNames(lists ...[]string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, name := range list {
+			name = strings.TrimSpace(name)
+			key := strings.ToLower(name)
+			if name == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// selectFields returns the requested fields from a work item's field map, keyed by the
+// reference name as the API returns it (e.g. "System.AreaPath"), however it was requested.
+// Lookups are case-insensitive; fields the work item does not have are omitted.
+func selectFields(fields map[string]any, names []string) map[string]any {
+	if len(names) == 0 || len(fields) == 0 {
+		return nil
+	}
+
+	lower := make(map[string]string, len(fields))
+	for k := range fields {
+		lower[strings.ToLower(k)] = k
+	}
+
+	selected := make(map[string]any)
+	for _, name := range names {
+		key, ok := lower[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		v := normalizeFieldValue(fields[key])
+		if v == nil {
+			continue
+		}
+		selected[key] = v
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
+
+// normalizeFieldValue flattens identity references to their display name and converts
+// HTML strings to plain text. Other values are returned unchanged.
+func normalizeFieldValue(v any) any {
+	switch val := v.(type) {
+	case string:
+		return normalizeContent(val)
+	case map[string]any:
+		if name, ok := val["displayName"].(string); ok {
+			return strings.TrimSpace(name)
+		}
+		return val
+	default:
+		return val
+	}
+}
@@ -0,0 +1,81 @@
+package adoworkitem
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeFieldNames(t *testing.T) {
+	t.Parallel()
+
+	got := mergeFieldNames(
+		[]string{"System.AreaPath", " Microsoft.VSTS.Common.Priority "},
+		[]string{"", "system.areapath", "Custom.Team"},
+	)
+	want := []string{"System.AreaPath", "Microsoft.VSTS.Common.Priority", "Custom.Team"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	t.Parallel()
+
+	fields := map[string]any{
+		"System.AreaPath":                          "Project\\Team",
+		"System.Tags":                              "api; backend",
+		"Microsoft.VSTS.Common.Priority":           float64(2),
+		"Microsoft.VSTS.Common.AcceptanceCriteria": "<div>Must <b>work</b><br/>offline</div>",
+		"Custom.Reviewer": map[string]any{
+			"displayName": "Jane Doe",
+			"uniqueName":  "jane@example.com",
+		},
+		"Custom.Blocked": false,
+	}
+
+	tests := []struct {
+		name  string
+		names []string
+		want  map[string]any
+	}{
+		{
+			name:  "no names",
+			names: nil,
+			want:  nil,
+		},
+		{
+			name:  "plain values keyed by canonical name",
+			names: []string{"system.areapath", "System.Tags", "Microsoft.VSTS.Common.Priority", "Custom.Blocked"},
+			want: map[string]any{
+				"System.AreaPath":                "Project\\Team",
+				"System.Tags":                    "api; backend",
+				"Microsoft.VSTS.Common.Priority": float64(2),
+				"Custom.Blocked":                 false,
+			},
+		},
+		{
+			name:  "identity flattened and html normalized",
+			names: []string{"Custom.Reviewer", "Microsoft.VSTS.Common.AcceptanceCriteria"},
+			want: map[string]any{
+				"Custom.Reviewer":                          "Jane Doe",
+				"Microsoft.VSTS.Common.AcceptanceCriteria": "Must work\noffline",
+			},
+		},
+		{
+			name:  "missing fields omitted",
+			names: []string{"Custom.DoesNotExist"},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := selectFields(fields, tt.names)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/krubenok/toolbox/internal/ado"
 )
 
 var (
@@ -32,9 +34,14 @@ type SimplifiedWorkItem struct {
 	AssignedTo  string `json:"assignedTo,omitempty"`
 	Description string `json:"description,omitempty"`
 
-	Discussion  []SimplifiedComment    `json:"discussion"`
-	Children    []SimplifiedChildLink  `json:"children"`
-	Attachments []SimplifiedAttachment `json:"attachments"`
+	// Fields holds additional fields requested by reference name (e.g. Microsoft.VSTS.Common.Priority).
+	Fields map[string]any `json:"fields,omitempty"`
+
+	Discussion        []SimplifiedComment    `json:"discussion"`
+	Children          []SimplifiedChildLink  `json:"children"`
+	ChildrenTruncated bool                   `json:"childrenTruncated,omitempty"`
+	Links             SimplifiedLinks        `json:"links"`
+	Attachments       []SimplifiedAttachment `json:"attachments"`
 }
 
 type SimplifiedComment struct {
@@ -49,12 +56,25 @@ type SimplifiedChildLink struct {
 	ID    int    `json:"id,omitempty"`
 	URL   string `json:"url,omitempty"`
 	UIURL string `json:"uiUrl,omitempty"`
+
+	// Resolved details, populated when children are fetched with a depth.
+	Title      string                `json:"title,omitempty"`
+	Type       string                `json:"type,omitempty"`
+	State      string                `json:"state,omitempty"`
+	AssignedTo string                `json:"assignedTo,omitempty"`
+	Children   []SimplifiedChildLink `json:"children,omitempty"`
 }
 
 type SimplifiedAttachment struct {
 	Name        string `json:"name,omitempty"`
 	URL         string `json:"url,omitempty"`
 	DownloadURL string `json:"downloadUrl,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+
+	// Populated when attachments are downloaded.
+	LocalPath string `json:"localPath,omitempty"`
+	Content   string `json:"content,omitempty"`
+	Note      string `json:"note,omitempty"`
 }
 
 func SimplifyWorkItem(parsed *ParsedWorkItem, wi WorkItemResponse, comments []WorkItemComment, baseURL string) SimplifiedWorkItem {
@@ -72,25 +92,31 @@ func SimplifyWorkItem(parsed *ParsedWorkItem, wi WorkItemResponse, comments []Wo
 		Description: normalizeContent(getStringField(wi.Fields, "System.Description")),
 		Discussion:  make([]SimplifiedComment, 0, len(comments)),
 		Children:    extractChildren(baseURL, parsed.Organization, parsed.Project, wi.Relations),
+		Links:       extractLinks(baseURL, parsed.Organization, parsed.Project, wi.Relations),
 		Attachments: extractAttachments(baseURL, parsed.Organization, parsed.Project, wi.Relations),
 	}
 
 	for _, c := range comments {
-		sc := SimplifiedComment{
-			ID:       c.ID,
-			Created:  c.CreatedDate,
-			Modified: c.ModifiedDate,
-			Text:     normalizeContent(c.Text),
-		}
-		if c.CreatedBy != nil {
-			sc.Author = c.CreatedBy.DisplayName
-		}
-		s.Discussion = append(s.Discussion, sc)
+		s.Discussion = append(s.Discussion, SimplifyComment(c))
 	}
 
 	return s
 }
 
+// SimplifyComment converts a raw work item comment to its simplified form.
+func SimplifyComment(c WorkItemComment) SimplifiedComment {
+	sc := SimplifiedComment{
+		ID:       c.ID,
+		Created:  c.CreatedDate,
+		Modified: c.ModifiedDate,
+		Text:     normalizeContent(c.Text),
+	}
+	if c.CreatedBy != nil {
+		sc.Author = c.CreatedBy.DisplayName
+	}
+	return sc
+}
+
 func getStringField(fields map[string]any, key string) string {
 	if fields == nil {
 		return ""
@@ -148,7 +174,7 @@ func extractChildren(baseURL, org, project string, relations []WorkItemRelation)
 func extractAttachments(baseURL, org, project string, relations []WorkItemRelation) []SimplifiedAttachment {
 	var attachments []SimplifiedAttachment
 	for _, r := range relations {
-		if r.Rel != "AttachedFile" {
+		if r.Rel != relAttachment {
 			continue
 		}
 		name := getStringAttr(r.Attributes, "name")
@@ -157,6 +183,7 @@ func extractAttachments(baseURL, org, project string, relations []WorkItemRelati
 			Name:        name,
 			URL:         r.URL,
 			DownloadURL: downloadURL,
+			Size:        getInt64Attr(r.Attributes, "resourceSize"),
 		})
 	}
 	if attachments == nil {
@@ -181,6 +208,16 @@ func getStringAttr(attrs map[string]any, key string) string {
 	return ""
 }
 
+func getInt64Attr(attrs map[string]any, key string) int64 {
+	if attrs == nil {
+		return 0
+	}
+	if v, ok := attrs[key].(float64); ok {
+		return int64(v)
+	}
+	return 0
+}
+
 func extractWorkItemIDFromRelationURL(relURL string) int {
 	if relURL == "" {
 		return 0
@@ -201,7 +238,7 @@ func extractWorkItemIDFromRelationURL(relURL string) int {
 	if err != nil {
 		return 0
 	}
-	parts := splitPath(u.Path)
+	parts := ado.SplitPath(u.Path)
 	for i := len(parts) - 1; i >= 0; i-- {
 		if strings.EqualFold(parts[i], "workitems") || strings.EqualFold(parts[i], "workItems") {
 			if i+1 < len(parts) {
@@ -232,11 +269,11 @@ func attachmentDownloadURL(baseURL, org, project, relURL, name string) string {
 		return ""
 	}
 
-	u := baseURL + "/" + url.PathEscape(org) + "/" + url.PathEscape(project) + "/_apis/wit/attachments/" + url.PathEscape(guid)
+	u := ado.NormalizeBaseURL(baseURL) + "/" + url.PathEscape(org) + "/" + url.PathEscape(project) + "/_apis/wit/attachments/" + url.PathEscape(guid)
 	if name != "" {
-		u += "?fileName=" + url.QueryEscape(name) + "&api-version=7.1-preview.3"
+		u += "?fileName=" + url.QueryEscape(name) + "&api-version=" + apiVersion
 	} else {
-		u += "?api-version=7.1-preview.3"
+		u += "?api-version=" + apiVersion
 	}
 	return u
 }
@@ -273,6 +310,14 @@ func WorkItemToMap(w SimplifiedWorkItem, cfg *OutputConfig) map[string]any {
 		m["description"] = w.Description
 	}
 
+	if shouldInclude(cfg, "fields", len(w.Fields) > 0) {
+		fields := w.Fields
+		if fields == nil {
+			fields = map[string]any{}
+		}
+		m["fields"] = fields
+	}
+
 	if shouldInclude(cfg, "discussion", len(w.Discussion) > 0) {
 		comments := make([]map[string]any, 0, len(w.Discussion))
 		for _, c := range w.Discussion {
@@ -288,6 +333,13 @@ func WorkItemToMap(w SimplifiedWorkItem, cfg *OutputConfig) map[string]any {
 		}
 		m["children"] = children
 	}
+	if w.ChildrenTruncated {
+		m["childrenTruncated"] = true
+	}
+
+	if shouldInclude(cfg, "links", !w.Links.IsEmpty()) {
+		m["links"] = LinksToMap(w.Links, cfg)
+	}
 
 	if shouldInclude(cfg, "attachments", len(w.Attachments) > 0) {
 		attachments := make([]map[string]any, 0, len(w.Attachments))
@@ -331,6 +383,25 @@ func ChildToMap(c SimplifiedChildLink, cfg *OutputConfig) map[string]any {
 	if shouldInclude(cfg, "childUiUrl", c.UIURL != "") {
 		m["childUiUrl"] = c.UIURL
 	}
+	if shouldInclude(cfg, "childTitle", c.Title != "") {
+		m["childTitle"] = c.Title
+	}
+	if shouldInclude(cfg, "childType", c.Type != "") {
+		m["childType"] = c.Type
+	}
+	if shouldInclude(cfg, "childState", c.State != "") {
+		m["childState"] = c.State
+	}
+	if shouldInclude(cfg, "childAssignedTo", c.AssignedTo != "") {
+		m["childAssignedTo"] = c.AssignedTo
+	}
+	if len(c.Children) > 0 {
+		children := make([]map[string]any, 0, len(c.Children))
+		for _, child := range c.Children {
+			children = append(children, ChildToMap(child, cfg))
+		}
+		m["children"] = children
+	}
 	return m
 }
 
@@ -345,6 +416,18 @@ func AttachmentToMap(a SimplifiedAttachment, cfg *OutputConfig) map[string]any {
 	if shouldInclude(cfg, "attachmentDownloadUrl", a.DownloadURL != "") {
 		m["attachmentDownloadUrl"] = a.DownloadURL
 	}
+	if shouldInclude(cfg, "attachmentSize", a.Size != 0) {
+		m["attachmentSize"] = a.Size
+	}
+	if shouldInclude(cfg, "attachmentPath", a.LocalPath != "") {
+		m["attachmentPath"] = a.LocalPath
+	}
+	if shouldInclude(cfg, "attachmentContent", a.Content != "") {
+		m["attachmentContent"] = a.Content
+	}
+	if shouldInclude(cfg, "attachmentNote", a.Note != "") {
+		m["attachmentNote"] = a.Note
+	}
 	return m
 }
 
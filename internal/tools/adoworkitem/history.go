@@ -0,0 +1,327 @@
+package adoworkitem
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// historyNoiseFields change on every revision and are left out of the timeline.
+var historyNoiseFields = map[string]bool{
+	"System.Rev":            true,
+	"System.ChangedDate":    true,
+	"System.ChangedBy":      true,
+	"System.AuthorizedDate": true,
+	"System.AuthorizedAs":   true,
+	"System.RevisedDate":    true,
+	"System.PersonId":       true,
+	"System.Watermark":      true,
+	"System.CommentCount":   true,
+}
+
+// maxDiffCells caps the table used to diff text values line by line: the changed lines
+// of the old value times the changed lines of the new one. Larger changes are shown in full.
+const maxDiffCells = 100_000
+
+// HistoryOptions configures a work item history lookup.
+type HistoryOptions struct {
+	Ctx         context.Context
+	WorkItemURL string
+
+	// Fields limits the timeline to changes of these field reference names (empty = all fields).
+	Fields []string
+	// MaxUpdates keeps only the most recent updates left after filtering (0 = no limit).
+	MaxUpdates int
+
+	OutputJSON bool
+	Debug      bool
+	DebugLog   func(string)
+}
+
+// HistoryResult contains the output from a history lookup.
+type HistoryResult struct {
+	History SimplifiedHistory
+	Output  string
+}
+
+// SimplifiedHistory is a work item's change timeline.
+type SimplifiedHistory struct {
+	ID      int                `json:"id"`
+	UIURL   string             `json:"uiUrl,omitempty"`
+	Updates []SimplifiedUpdate `json:"updates"`
+}
+
+// SimplifiedUpdate is a single revision: who changed what and when.
+type SimplifiedUpdate struct {
+	Rev          int           `json:"rev"`
+	By           string        `json:"by,omitempty"`
+	Date         string        `json:"date,omitempty"`
+	Changes      []FieldChange `json:"changes,omitempty"`
+	LinksAdded   []string      `json:"linksAdded,omitempty"`
+	LinksRemoved []string      `json:"linksRemoved,omitempty"`
+}
+
+// FieldChange is a change to a single field. Multi-line text values are reported as a
+// line diff ("-" removed, "+" added) in Diff instead of From/To.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from,omitempty"`
+	To    string `json:"to,omitempty"`
+	Diff  string `json:"diff,omitempty"`
+}
+
+// History fetches a work item's updates and renders them as a field change timeline.
+func History(opts HistoryOptions) (*HistoryResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	parsed, err := ParseWorkItemURL(opts.WorkItemURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig(parsed.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newRunClient(parsed.BaseURL, parsed.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	updates, err := client.FetchUpdates(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	history := SimplifyHistory(updates, mergeFieldNames(opts.Fields), opts.MaxUpdates)
+	history.ID = parsed.ID
+	history.UIURL = UIWorkItemURL(client.BaseURL(), parsed.Organization, parsed.Project, parsed.ID)
+
+	output, err := marshalOutput(history, HistoryToMap(history, cfg.Output), opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoryResult{History: history, Output: output}, nil
+}
+
+// SimplifyHistory converts raw updates to a timeline. If fields is non-empty, only changes
+// to those fields (case-insensitive) are kept. Updates with nothing left to show are dropped,
+// and a maxUpdates above 0 then keeps only the most recent maxUpdates of the rest.
+func SimplifyHistory(updates []WorkItemUpdate, fields []string, maxUpdates int) SimplifiedHistory {
+	want := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		want[strings.ToLower(f)] = true
+	}
+
+	h := SimplifiedHistory{Updates: []SimplifiedUpdate{}}
+	for _, u := range updates {
+		su := SimplifiedUpdate{
+			Rev:  u.Rev,
+			Date: updateDate(u),
+		}
+		if u.RevisedBy != nil {
+			su.By = u.RevisedBy.DisplayName
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(u.Fields)) {
+			if historyNoiseFields[name] || (len(want) > 0 && !want[strings.ToLower(name)]) {
+				continue
+			}
+			if change, ok := fieldChange(name, u.Fields[name]); ok {
+				su.Changes = append(su.Changes, change)
+			}
+		}
+
+		if u.Relations != nil && len(want) == 0 {
+			for _, r := range u.Relations.Added {
+				su.LinksAdded = append(su.LinksAdded, describeRelation(r))
+			}
+			for _, r := range u.Relations.Removed {
+				su.LinksRemoved = append(su.LinksRemoved, describeRelation(r))
+			}
+		}
+
+		if len(su.Changes) == 0 && len(su.LinksAdded) == 0 && len(su.LinksRemoved) == 0 {
+			continue
+		}
+		h.Updates = append(h.Updates, su)
+	}
+	if maxUpdates > 0 && len(h.Updates) > maxUpdates {
+		h.Updates = h.Updates[len(h.Updates)-maxUpdates:]
+	}
+	return h
+}
+
+// updateDate returns when the update was made. RevisedDate on an update is the end of the
+// revision's lifetime, so System.ChangedDate is preferred when present.
+func updateDate(u WorkItemUpdate) string {
+	if fv, ok := u.Fields["System.ChangedDate"]; ok {
+		if s, ok := fv.NewValue.(string); ok && s != "" {
+			return s
+		}
+	}
+	if strings.HasPrefix(u.RevisedDate, "9999-") {
+		return ""
+	}
+	return u.RevisedDate
+}
+
+func fieldChange(name string, fv WorkItemFieldUpdate) (FieldChange, bool) {
+	from := formatFieldValue(fv.OldValue)
+	to := formatFieldValue(fv.NewValue)
+	if from == to {
+		return FieldChange{}, false
+	}
+
+	change := FieldChange{Field: name, From: from, To: to}
+	if strings.Contains(from, "\n") || strings.Contains(to, "\n") {
+		if diff, ok := lineDiff(from, to); ok {
+			change.From, change.To, change.Diff = "", "", diff
+		}
+	}
+	return change, true
+}
+
+// formatFieldValue renders a field value as text, flattening identities and converting HTML.
+func formatFieldValue(v any) string {
+	switch val := normalizeFieldValue(v).(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// describeRelation renders a link change, e.g. "Parent #123" or "Attachment log.txt".
+func describeRelation(r WorkItemRelation) string {
+	name := getStringAttr(r.Attributes, "name")
+	if r.Rel == relAttachment && name != "" {
+		return "Attachment " + name
+	}
+	if name == "" {
+		name = r.Rel
+	}
+	if r.Rel != relArtifact && r.Rel != relHyperlink && r.Rel != relAttachment {
+		if id := extractWorkItemIDFromRelationURL(r.URL); id != 0 {
+			return name + " #" + strconv.Itoa(id)
+		}
+	}
+	return name + " " + r.URL
+}
+
+// lineDiff returns a minimal line diff between a and b, with removed lines prefixed by "-"
+// and added lines by "+". Unchanged lines are omitted. It reports false when the changed
+// part is too large to diff.
+func lineDiff(a, b string) (string, bool) {
+	x := strings.Split(a, "\n")
+	y := strings.Split(b, "\n")
+	if a == "" {
+		x = nil
+	}
+	if b == "" {
+		y = nil
+	}
+
+	// Lines shared at either end are unchanged, so only the middle needs the table.
+	for len(x) > 0 && len(y) > 0 && x[0] == y[0] {
+		x, y = x[1:], y[1:]
+	}
+	for len(x) > 0 && len(y) > 0 && x[len(x)-1] == y[len(y)-1] {
+		x, y = x[:len(x)-1], y[:len(y)-1]
+	}
+	if len(x)*len(y) > maxDiffCells {
+		return "", false
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []string
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			out = append(out, "-"+x[i])
+			i++
+		default:
+			out = append(out, "+"+y[j])
+			j++
+		}
+	}
+	return strings.Join(out, "\n"), true
+}
+
+// HistoryToMap converts a SimplifiedHistory to a map based on output config.
+// Change columns are always present so each update's changes render as a compact table.
+func HistoryToMap(h SimplifiedHistory, cfg *OutputConfig) map[string]any {
+	updates := make([]map[string]any, 0, len(h.Updates))
+	for _, u := range h.Updates {
+		m := make(map[string]any)
+		if shouldInclude(cfg, "rev", u.Rev != 0) {
+			m["rev"] = u.Rev
+		}
+		if shouldInclude(cfg, "by", u.By != "") {
+			m["by"] = u.By
+		}
+		if shouldInclude(cfg, "date", u.Date != "") {
+			m["date"] = u.Date
+		}
+		if shouldInclude(cfg, "changes", len(u.Changes) > 0) {
+			changes := make([]map[string]any, 0, len(u.Changes))
+			hasDiff := false
+			for _, c := range u.Changes {
+				hasDiff = hasDiff || c.Diff != ""
+			}
+			for _, c := range u.Changes {
+				cm := map[string]any{"field": c.Field, "from": c.From, "to": c.To}
+				if hasDiff {
+					cm["diff"] = c.Diff
+				}
+				changes = append(changes, cm)
+			}
+			m["changes"] = changes
+		}
+		if shouldInclude(cfg, "linksAdded", len(u.LinksAdded) > 0) {
+			m["linksAdded"] = u.LinksAdded
+		}
+		if shouldInclude(cfg, "linksRemoved", len(u.LinksRemoved) > 0) {
+			m["linksRemoved"] = u.LinksRemoved
+		}
+		updates = append(updates, m)
+	}
+
+	m := map[string]any{"updates": updates}
+	if shouldInclude(cfg, "id", h.ID != 0) {
+		m["id"] = h.ID
+	}
+	if shouldInclude(cfg, "uiUrl", h.UIURL != "") {
+		m["uiUrl"] = h.UIURL
+	}
+	return m
+}
@@ -0,0 +1,263 @@
+package adoworkitem
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestSimplifyHistory(t *testing.T) {
+	t.Parallel()
+
+	updates := []WorkItemUpdate{
+		{
+			Rev:         1,
+			RevisedBy:   &IdentityRef{DisplayName: "Jane Doe"},
+			RevisedDate: "2025-01-02T00:00:00Z",
+			Fields: map[string]WorkItemFieldUpdate{
+				"System.Rev":         {NewValue: float64(1)},
+				"System.ChangedDate": {NewValue: "2025-01-01T00:00:00Z"},
+				"System.State":       {NewValue: "New"},
+				"System.Title":       {NewValue: "Crash on save"},
+			},
+		},
+		{
+			Rev:         2,
+			RevisedBy:   &IdentityRef{DisplayName: "John Roe"},
+			RevisedDate: "9999-01-01T00:00:00Z",
+			Fields: map[string]WorkItemFieldUpdate{
+				"System.ChangedDate": {OldValue: "2025-01-01T00:00:00Z", NewValue: "2025-01-03T00:00:00Z"},
+				"System.State":       {OldValue: "New", NewValue: "Active"},
+				"System.AssignedTo": {
+					OldValue: map[string]any{"displayName": "Jane Doe"},
+					NewValue: map[string]any{"displayName": "John Roe"},
+				},
+				"Microsoft.VSTS.Common.Priority": {OldValue: float64(2), NewValue: float64(1)},
+				"System.Description": {
+					OldValue: "<div>Steps:</div><div>open</div><div>save</div>",
+					NewValue: "<div>Steps:</div><div>open</div><div>edit</div><div>save</div>",
+				},
+			},
+			Relations: &WorkItemRelationUpdates{
+				Added: []WorkItemRelation{
+					{Rel: "System.LinkTypes.Hierarchy-Reverse", URL: "https://dev.azure.com/org/_apis/wit/workItems/7", Attributes: map[string]any{"name": "Parent"}},
+				},
+				Removed: []WorkItemRelation{
+					{Rel: "AttachedFile", URL: "https://dev.azure.com/org/_apis/wit/attachments/guid", Attributes: map[string]any{"name": "log.txt"}},
+				},
+			},
+		},
+		{
+			// Only noise fields changed.
+			Rev:    3,
+			Fields: map[string]WorkItemFieldUpdate{"System.CommentCount": {OldValue: float64(0), NewValue: float64(1)}},
+		},
+	}
+
+	t.Run("all fields", func(t *testing.T) {
+		t.Parallel()
+
+		got := SimplifyHistory(updates, nil, 0)
+		want := []SimplifiedUpdate{
+			{
+				Rev:  1,
+				By:   "Jane Doe",
+				Date: "2025-01-01T00:00:00Z",
+				Changes: []FieldChange{
+					{Field: "System.State", To: "New"},
+					{Field: "System.Title", To: "Crash on save"},
+				},
+			},
+			{
+				Rev:  2,
+				By:   "John Roe",
+				Date: "2025-01-03T00:00:00Z",
+				Changes: []FieldChange{
+					{Field: "Microsoft.VSTS.Common.Priority", From: "2", To: "1"},
+					{Field: "System.AssignedTo", From: "Jane Doe", To: "John Roe"},
+					{Field: "System.Description", Diff: "+edit"},
+					{Field: "System.State", From: "New", To: "Active"},
+				},
+				LinksAdded:   []string{"Parent #7"},
+				LinksRemoved: []string{"Attachment log.txt"},
+			},
+		}
+		if !reflect.DeepEqual(got.Updates, want) {
+			t.Fatalf("got %+v\nwant %+v", got.Updates, want)
+		}
+	})
+
+	t.Run("field filter", func(t *testing.T) {
+		t.Parallel()
+
+		got := SimplifyHistory(updates, []string{"system.state"}, 0)
+		if len(got.Updates) != 2 {
+			t.Fatalf("got %d updates, want 2", len(got.Updates))
+		}
+		for _, u := range got.Updates {
+			if len(u.Changes) != 1 || u.Changes[0].Field != "System.State" || len(u.LinksAdded) != 0 {
+				t.Fatalf("unexpected update %+v", u)
+			}
+		}
+	})
+
+	t.Run("limit applies after filtering", func(t *testing.T) {
+		t.Parallel()
+
+		// The newest update only changes a noise field, so it does not count.
+		got := SimplifyHistory(updates, nil, 1)
+		if len(got.Updates) != 1 || got.Updates[0].Rev != 2 {
+			t.Fatalf("got %+v, want only rev 2", got.Updates)
+		}
+		got = SimplifyHistory(updates, []string{"System.Title"}, 1)
+		if len(got.Updates) != 1 || got.Updates[0].Rev != 1 {
+			t.Fatalf("got %+v, want only rev 1", got.Updates)
+		}
+	})
+}
+
+func TestHistoryToMap(t *testing.T) {
+	t.Parallel()
+
+	h := SimplifiedHistory{
+		ID:    5,
+		UIURL: "https://dev.azure.com/org/project/_workitems/edit/5",
+		Updates: []SimplifiedUpdate{
+			{Rev: 2, By: "Jane", Date: "2025-01-03T14:12:00Z", Changes: []FieldChange{{Field: "System.State", From: "New", To: "Active"}}, LinksAdded: []string{"Parent #1"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		cfg     *OutputConfig
+		want    []string
+		wantOut []string
+	}{
+		{
+			name: "defaults",
+			cfg:  DefaultOutputConfig(),
+			want: []string{"rev", "by", "date", "changes", "linksAdded"},
+		},
+		{
+			name:    "hidden fields",
+			cfg:     &OutputConfig{Date: FieldModeNever, LinksAdded: FieldModeNever},
+			want:    []string{"rev", "by", "changes"},
+			wantOut: []string{"date", "linksAdded"},
+		},
+		{
+			name: "always",
+			cfg:  &OutputConfig{LinksRemoved: FieldModeAlways},
+			want: []string{"rev", "linksRemoved"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := HistoryToMap(h, tt.cfg)
+			if m["id"] != 5 {
+				t.Fatalf("id = %v, want 5", m["id"])
+			}
+			update := m["updates"].([]map[string]any)[0]
+			for _, key := range tt.want {
+				if _, ok := update[key]; !ok {
+					t.Errorf("update missing %q: %v", key, update)
+				}
+			}
+			for _, key := range tt.wantOut {
+				if _, ok := update[key]; ok {
+					t.Errorf("update has %q, want it hidden: %v", key, update)
+				}
+			}
+		})
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{name: "added line", a: "a\nb", b: "a\nx\nb", want: "+x"},
+		{name: "removed line", a: "a\nb\nc", b: "a\nc", want: "-b"},
+		{name: "replaced line", a: "a\nb\nc", b: "a\nB\nc", want: "-b\n+B"},
+		{name: "from empty", a: "", b: "a\nb", want: "+a\n+b"},
+		{name: "to empty", a: "a\nb", b: "", want: "-a\n-b"},
+		{name: "edit in long text", a: strings.Repeat("x\n", 5000) + "old" + strings.Repeat("\ny", 5000), b: strings.Repeat("x\n", 5000) + "new" + strings.Repeat("\ny", 5000), want: "-old\n+new"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := lineDiff(tt.a, tt.b)
+			if !ok || got != tt.want {
+				t.Fatalf("lineDiff = %q, %v; want %q", got, ok, tt.want)
+			}
+		})
+	}
+
+	// A rewrite of a long text is shown in full rather than diffed.
+	var a, b []string
+	for i := 0; i < 400; i++ {
+		a = append(a, "a"+strconv.Itoa(i))
+		b = append(b, "b"+strconv.Itoa(i))
+	}
+	if _, ok := lineDiff(strings.Join(a, "\n"), strings.Join(b, "\n")); ok {
+		t.Fatal("lineDiff diffed a change larger than maxDiffCells")
+	}
+}
+
+func TestClientFetchUpdatesPages(t *testing.T) {
+	t.Parallel()
+
+	var skips []string
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "pat"}, "", false, nil)
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(r.URL.Path, "/org/project/_apis/wit/workItems/5/updates") {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		skip := r.URL.Query().Get("$skip")
+		skips = append(skips, skip)
+
+		n := 200
+		if skip == "200" {
+			n = 3
+		}
+		resp := WorkItemUpdatesResponse{Count: n}
+		for i := 0; i < n; i++ {
+			resp.Value = append(resp.Value, WorkItemUpdate{Rev: len(skips)*1000 + i})
+		}
+		b, _ := json.Marshal(resp)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(string(b))),
+			Request:    r,
+		}, nil
+	}))
+
+	client := NewClient(api)
+	got, err := client.FetchUpdates(context.Background(), &ParsedWorkItem{Organization: "org", Project: "project", ID: 5})
+	if err != nil {
+		t.Fatalf("FetchUpdates: %v", err)
+	}
+	if len(got) != 203 {
+		t.Fatalf("got %d updates, want 203", len(got))
+	}
+	if !reflect.DeepEqual(skips, []string{"", "200"}) {
+		t.Fatalf("skips = %v", skips)
+	}
+}
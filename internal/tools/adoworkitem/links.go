@@ -0,0 +1,234 @@
+package adoworkitem
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/krubenok/toolbox/internal/ado"
+)
+
+// Work item link relation types that are grouped into their own sections.
+// Child links (Hierarchy-Forward) and attachments are reported separately.
+const (
+	relParent      = "System.LinkTypes.Hierarchy-Reverse"
+	relRelated     = "System.LinkTypes.Related"
+	relPredecessor = "System.LinkTypes.Dependency-Reverse"
+	relSuccessor   = "System.LinkTypes.Dependency-Forward"
+	relArtifact    = "ArtifactLink"
+	relHyperlink   = "Hyperlink"
+	relAttachment  = "AttachedFile"
+)
+
+// SimplifiedLinks groups a work item's non-child links by relation type.
+type SimplifiedLinks struct {
+	Parent       []SimplifiedLink `json:"parent"`
+	Related      []SimplifiedLink `json:"related"`
+	Predecessors []SimplifiedLink `json:"predecessors"`
+	Successors   []SimplifiedLink `json:"successors"`
+	PullRequests []SimplifiedLink `json:"pullRequests"`
+	Commits      []SimplifiedLink `json:"commits"`
+	Builds       []SimplifiedLink `json:"builds"`
+	Other        []SimplifiedLink `json:"otherLinks"`
+}
+
+// SimplifiedLink is a single link to another work item or artifact.
+// ID is the work item ID, PR ID, commit SHA or build ID; URL is a clickable web URL
+// when the target could be decoded, otherwise the raw relation URL.
+type SimplifiedLink struct {
+	ID      string `json:"id,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// IsEmpty reports whether no links were found in any group.
+func (l SimplifiedLinks) IsEmpty() bool {
+	return len(l.Parent) == 0 && len(l.Related) == 0 &&
+		len(l.Predecessors) == 0 && len(l.Successors) == 0 &&
+		len(l.PullRequests) == 0 && len(l.Commits) == 0 &&
+		len(l.Builds) == 0 && len(l.Other) == 0
+}
+
+// emptyLinks returns SimplifiedLinks with every group initialized to an empty slice,
+// so JSON output has a stable shape.
+func emptyLinks() SimplifiedLinks {
+	return SimplifiedLinks{
+		Parent:       []SimplifiedLink{},
+		Related:      []SimplifiedLink{},
+		Predecessors: []SimplifiedLink{},
+		Successors:   []SimplifiedLink{},
+		PullRequests: []SimplifiedLink{},
+		Commits:      []SimplifiedLink{},
+		Builds:       []SimplifiedLink{},
+		Other:        []SimplifiedLink{},
+	}
+}
+
+func extractLinks(baseURL, org, project string, relations []WorkItemRelation) SimplifiedLinks {
+	links := emptyLinks()
+	for _, r := range relations {
+		if isChildRelation(r.Rel) || r.Rel == relAttachment {
+			continue
+		}
+		comment := getStringAttr(r.Attributes, "comment")
+
+		switch r.Rel {
+		case relParent, relRelated, relPredecessor, relSuccessor:
+			link, ok := workItemLink(baseURL, org, project, r.URL)
+			if !ok {
+				continue
+			}
+			link.Comment = comment
+			switch r.Rel {
+			case relParent:
+				links.Parent = append(links.Parent, link)
+			case relRelated:
+				links.Related = append(links.Related, link)
+			case relPredecessor:
+				links.Predecessors = append(links.Predecessors, link)
+			case relSuccessor:
+				links.Successors = append(links.Successors, link)
+			}
+			continue
+		case relArtifact:
+			if kind, link, ok := decodeArtifactLink(baseURL, org, project, r.URL); ok {
+				link.Comment = comment
+				switch kind {
+				case artifactPullRequest:
+					links.PullRequests = append(links.PullRequests, link)
+				case artifactCommit:
+					links.Commits = append(links.Commits, link)
+				case artifactBuild:
+					links.Builds = append(links.Builds, link)
+				}
+				continue
+			}
+		}
+
+		// Anything else (hyperlinks, branches, duplicates, test links, ...) is kept as-is.
+		link := SimplifiedLink{
+			URL:     r.URL,
+			Type:    getStringAttr(r.Attributes, "name"),
+			Comment: comment,
+		}
+		if link.Type == "" {
+			link.Type = r.Rel
+		}
+		if r.Rel != relHyperlink && r.Rel != relArtifact {
+			if id := extractWorkItemIDFromRelationURL(r.URL); id != 0 {
+				link.ID = strconv.Itoa(id)
+				link.URL = UIWorkItemURL(baseURL, org, project, id)
+			}
+		}
+		links.Other = append(links.Other, link)
+	}
+
+	return links
+}
+
+func workItemLink(baseURL, org, project, relURL string) (SimplifiedLink, bool) {
+	id := extractWorkItemIDFromRelationURL(relURL)
+	if id == 0 {
+		return SimplifiedLink{}, false
+	}
+	return SimplifiedLink{
+		ID:  strconv.Itoa(id),
+		URL: UIWorkItemURL(baseURL, org, project, id),
+	}, true
+}
+
+type artifactKind int
+
+const (
+	artifactPullRequest artifactKind = iota + 1
+	artifactCommit
+	artifactBuild
+)
+
+// decodeArtifactLink converts a vstfs artifact URI into a web URL. Supported forms:
+//
+//	vstfs:///Git/PullRequestId/{projectId}%2F{repositoryId}%2F{pullRequestId}
+//	vstfs:///Git/Commit/{projectId}%2F{repositoryId}%2F{commitId}
+//	vstfs:///Build/Build/{buildId}
+func decodeArtifactLink(baseURL, org, project, uri string) (artifactKind, SimplifiedLink, bool) {
+	const prefix = "vstfs:///"
+	if !strings.HasPrefix(uri, prefix) {
+		return 0, SimplifiedLink{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(uri, prefix), "/", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return 0, SimplifiedLink{}, false
+	}
+	tool, artifact, id := parts[0], parts[1], parts[2]
+	base := ado.NormalizeBaseURL(baseURL) + "/" + url.PathEscape(org)
+
+	switch {
+	case strings.EqualFold(tool, "Git") && (strings.EqualFold(artifact, "PullRequestId") || strings.EqualFold(artifact, "Commit")):
+		decoded, err := url.PathUnescape(id)
+		if err != nil {
+			return 0, SimplifiedLink{}, false
+		}
+		segs := strings.Split(decoded, "/")
+		if len(segs) != 3 || segs[0] == "" || segs[1] == "" || segs[2] == "" {
+			return 0, SimplifiedLink{}, false
+		}
+		repoURL := base + "/" + url.PathEscape(segs[0]) + "/_git/" + url.PathEscape(segs[1])
+		if strings.EqualFold(artifact, "PullRequestId") {
+			return artifactPullRequest, SimplifiedLink{ID: segs[2], URL: repoURL + "/pullrequest/" + url.PathEscape(segs[2])}, true
+		}
+		return artifactCommit, SimplifiedLink{ID: segs[2], URL: repoURL + "/commit/" + url.PathEscape(segs[2])}, true
+	case strings.EqualFold(tool, "Build") && strings.EqualFold(artifact, "Build"):
+		if _, err := strconv.Atoi(id); err != nil {
+			return 0, SimplifiedLink{}, false
+		}
+		return artifactBuild, SimplifiedLink{ID: id, URL: base + "/" + url.PathEscape(project) + "/_build/results?buildId=" + id}, true
+	}
+	return 0, SimplifiedLink{}, false
+}
+
+// LinksToMap converts SimplifiedLinks to a map based on output config.
+func LinksToMap(l SimplifiedLinks, cfg *OutputConfig) map[string]any {
+	m := make(map[string]any)
+	groups := []struct {
+		key   string
+		links []SimplifiedLink
+	}{
+		{"parent", l.Parent},
+		{"related", l.Related},
+		{"predecessors", l.Predecessors},
+		{"successors", l.Successors},
+		{"pullRequests", l.PullRequests},
+		{"commits", l.Commits},
+		{"builds", l.Builds},
+		{"otherLinks", l.Other},
+	}
+	for _, g := range groups {
+		if !shouldInclude(cfg, g.key, len(g.links) > 0) {
+			continue
+		}
+		items := make([]map[string]any, 0, len(g.links))
+		for _, link := range g.links {
+			items = append(items, LinkToMap(link, cfg))
+		}
+		m[g.key] = items
+	}
+	return m
+}
+
+func LinkToMap(l SimplifiedLink, cfg *OutputConfig) map[string]any {
+	m := make(map[string]any)
+	if shouldInclude(cfg, "linkId", l.ID != "") {
+		m["linkId"] = l.ID
+	}
+	if shouldInclude(cfg, "linkUrl", l.URL != "") {
+		m["linkUrl"] = l.URL
+	}
+	if shouldInclude(cfg, "linkType", l.Type != "") {
+		m["linkType"] = l.Type
+	}
+	if shouldInclude(cfg, "linkComment", l.Comment != "") {
+		m["linkComment"] = l.Comment
+	}
+	return m
+}
@@ -0,0 +1,90 @@
+package adoworkitem
+
+import (
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	t.Parallel()
+
+	relations := []WorkItemRelation{
+		{Rel: "System.LinkTypes.Hierarchy-Forward", URL: "https://dev.azure.com/org/_apis/wit/workItems/10"},
+		{Rel: "System.LinkTypes.Hierarchy-Reverse", URL: "https://dev.azure.com/org/_apis/wit/workItems/1"},
+		{Rel: "System.LinkTypes.Related", URL: "https://dev.azure.com/org/_apis/wit/workItems/2", Attributes: map[string]any{"comment": "see also"}},
+		{Rel: "System.LinkTypes.Dependency-Reverse", URL: "https://dev.azure.com/org/_apis/wit/workItems/3"},
+		{Rel: "System.LinkTypes.Dependency-Forward", URL: "https://dev.azure.com/org/_apis/wit/workItems/4"},
+		{Rel: "ArtifactLink", URL: "vstfs:///Git/PullRequestId/proj-guid%2Frepo-guid%2F42", Attributes: map[string]any{"name": "Pull Request"}},
+		{Rel: "ArtifactLink", URL: "vstfs:///Git/Commit/proj-guid%2Frepo-guid%2Fabc123", Attributes: map[string]any{"name": "Fixed in Commit"}},
+		{Rel: "ArtifactLink", URL: "vstfs:///Build/Build/987", Attributes: map[string]any{"name": "Build"}},
+		{Rel: "ArtifactLink", URL: "vstfs:///Git/Ref/proj-guid%2Frepo-guid%2FGBmain", Attributes: map[string]any{"name": "Branch"}},
+		{Rel: "Hyperlink", URL: "https://example.com/spec"},
+		{Rel: "System.LinkTypes.Duplicate-Forward", URL: "https://dev.azure.com/org/_apis/wit/workItems/5", Attributes: map[string]any{"name": "Duplicate"}},
+		{Rel: "AttachedFile", URL: "https://dev.azure.com/org/_apis/wit/attachments/guid"},
+	}
+
+	got := extractLinks("https://dev.azure.com", "org", "proj", relations)
+
+	check := func(group string, links []SimplifiedLink, want []SimplifiedLink) {
+		t.Helper()
+		if len(links) != len(want) {
+			t.Fatalf("%s: got %d links (%+v), want %d", group, len(links), links, len(want))
+		}
+		for i := range want {
+			if links[i] != want[i] {
+				t.Fatalf("%s[%d]: got %+v, want %+v", group, i, links[i], want[i])
+			}
+		}
+	}
+
+	check("parent", got.Parent, []SimplifiedLink{{ID: "1", URL: "https://dev.azure.com/org/proj/_workitems/edit/1"}})
+	check("related", got.Related, []SimplifiedLink{{ID: "2", URL: "https://dev.azure.com/org/proj/_workitems/edit/2", Comment: "see also"}})
+	check("predecessors", got.Predecessors, []SimplifiedLink{{ID: "3", URL: "https://dev.azure.com/org/proj/_workitems/edit/3"}})
+	check("successors", got.Successors, []SimplifiedLink{{ID: "4", URL: "https://dev.azure.com/org/proj/_workitems/edit/4"}})
+	check("pullRequests", got.PullRequests, []SimplifiedLink{{ID: "42", URL: "https://dev.azure.com/org/proj-guid/_git/repo-guid/pullrequest/42"}})
+	check("commits", got.Commits, []SimplifiedLink{{ID: "abc123", URL: "https://dev.azure.com/org/proj-guid/_git/repo-guid/commit/abc123"}})
+	check("builds", got.Builds, []SimplifiedLink{{ID: "987", URL: "https://dev.azure.com/org/proj/_build/results?buildId=987"}})
+	check("other", got.Other, []SimplifiedLink{
+		{URL: "vstfs:///Git/Ref/proj-guid%2Frepo-guid%2FGBmain", Type: "Branch"},
+		{URL: "https://example.com/spec", Type: "Hyperlink"},
+		{ID: "5", URL: "https://dev.azure.com/org/proj/_workitems/edit/5", Type: "Duplicate"},
+	})
+}
+
+func TestDecodeArtifactLinkRejectsMalformed(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"",
+		"https://example.com",
+		"vstfs:///Git/PullRequestId/",
+		"vstfs:///Git/PullRequestId/proj%2F42",
+		"vstfs:///Build/Build/notanumber",
+		"vstfs:///WorkItemTracking/WorkItem/1",
+	}
+
+	for _, in := range tests {
+		in := in
+		t.Run(in, func(t *testing.T) {
+			t.Parallel()
+			if kind, link, ok := decodeArtifactLink("https://dev.azure.com", "org", "proj", in); ok {
+				t.Fatalf("decodeArtifactLink(%q) = %v, %+v; want not ok", in, kind, link)
+			}
+		})
+	}
+}
+
+func TestLinksToMapOmitsEmptyGroups(t *testing.T) {
+	t.Parallel()
+
+	links := emptyLinks()
+	links.Parent = []SimplifiedLink{{ID: "1", URL: "https://dev.azure.com/org/proj/_workitems/edit/1"}}
+
+	m := LinksToMap(links, DefaultOutputConfig())
+	if len(m) != 1 {
+		t.Fatalf("got keys %v, want only parent", m)
+	}
+	parent, ok := m["parent"].([]map[string]any)
+	if !ok || len(parent) != 1 || parent[0]["linkId"] != "1" {
+		t.Fatalf("unexpected parent group: %#v", m["parent"])
+	}
+}
@@ -0,0 +1,129 @@
+package adoworkitem
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	reMDHeading     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	reMDBullet      = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	reMDOrdered     = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+	reMDQuote       = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	reMDRule        = regexp.MustCompile(`^\s*(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	reMDInlineCode  = regexp.MustCompile("`([^`]+)`")
+	reMDBold        = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	reMDItalic      = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_\s][^_]*)_\b`)
+	reMDLink        = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	reMDPlaceholder = regexp.MustCompile("\x00(\\d+)\x00")
+)
+
+// markdownToHTML converts the common subset of markdown used in work item descriptions
+// (headings, paragraphs, lists, quotes, fenced code, rules, and inline code, bold,
+// italic and links) to the HTML that Azure DevOps stores for rich text fields.
+// Line breaks inside a paragraph are kept as <br/>.
+func markdownToHTML(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	var out []string
+	var para []string
+	var listTag string
+
+	flushPara := func() {
+		if len(para) > 0 {
+			out = append(out, "<p>"+strings.Join(para, "<br/>")+"</p>")
+			para = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			out = append(out, "</"+listTag+">")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			out = append(out, "<"+tag+">")
+			listTag = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			flushPara()
+			closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, html.EscapeString(lines[i]))
+			}
+			out = append(out, "<pre><code>"+strings.Join(code, "\n")+"</code></pre>")
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flushPara()
+			closeList()
+		case reMDRule.MatchString(line):
+			flushPara()
+			closeList()
+			out = append(out, "<hr/>")
+		case reMDHeading.MatchString(trimmed):
+			flushPara()
+			closeList()
+			m := reMDHeading.FindStringSubmatch(trimmed)
+			level := strconv.Itoa(len(m[1]))
+			out = append(out, "<h"+level+">"+renderInlineMarkdown(m[2])+"</h"+level+">")
+		case reMDBullet.MatchString(line):
+			flushPara()
+			openList("ul")
+			out = append(out, "<li>"+renderInlineMarkdown(reMDBullet.FindStringSubmatch(line)[1])+"</li>")
+		case reMDOrdered.MatchString(line):
+			flushPara()
+			openList("ol")
+			out = append(out, "<li>"+renderInlineMarkdown(reMDOrdered.FindStringSubmatch(line)[1])+"</li>")
+		case reMDQuote.MatchString(line):
+			flushPara()
+			closeList()
+			var quote []string
+			for ; i < len(lines) && reMDQuote.MatchString(lines[i]); i++ {
+				quote = append(quote, renderInlineMarkdown(reMDQuote.FindStringSubmatch(lines[i])[1]))
+			}
+			i--
+			out = append(out, "<blockquote>"+strings.Join(quote, "<br/>")+"</blockquote>")
+		default:
+			closeList()
+			para = append(para, renderInlineMarkdown(trimmed))
+		}
+	}
+	flushPara()
+	closeList()
+
+	return strings.Join(out, "")
+}
+
+// renderInlineMarkdown escapes text and converts inline code, links, bold and italic.
+// Code spans are swapped out for placeholders first so their contents are not formatted.
+func renderInlineMarkdown(s string) string {
+	var codes []string
+	s = reMDInlineCode.ReplaceAllStringFunc(s, func(m string) string {
+		codes = append(codes, "<code>"+html.EscapeString(m[1:len(m)-1])+"</code>")
+		return "\x00" + strconv.Itoa(len(codes)-1) + "\x00"
+	})
+
+	s = html.EscapeString(s)
+	s = reMDLink.ReplaceAllString(s, `<a href="$2">$1</a>`)
+	s = reMDBold.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = reMDItalic.ReplaceAllString(s, "<em>$1$2</em>")
+
+	return reMDPlaceholder.ReplaceAllStringFunc(s, func(m string) string {
+		idx, _ := strconv.Atoi(m[1 : len(m)-1])
+		return codes[idx]
+	})
+}
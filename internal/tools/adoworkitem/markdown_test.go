@@ -0,0 +1,56 @@
+package adoworkitem
+
+import (
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "paragraphs and line breaks",
+			in:   "First line\nsecond line\n\nNext paragraph",
+			want: "<p>First line<br/>second line</p><p>Next paragraph</p>",
+		},
+		{
+			name: "heading and lists",
+			in:   "## Repro\n1. Open app\n2. Click **Save**\n\n- one\n- two",
+			want: "<h2>Repro</h2><ol><li>Open app</li><li>Click <strong>Save</strong></li></ol><ul><li>one</li><li>two</li></ul>",
+		},
+		{
+			name: "inline formatting and escaping",
+			in:   "Use `a < b` and *care* with [docs](https://example.com/?a=1&b=2) & <script>",
+			want: `<p>Use <code>a &lt; b</code> and <em>care</em> with <a href="https://example.com/?a=1&amp;b=2">docs</a> &amp; &lt;script&gt;</p>`,
+		},
+		{
+			name: "fenced code is escaped verbatim",
+			in:   "```go\nif a < b && **c** {\n}\n```",
+			want: "<pre><code>if a &lt; b &amp;&amp; **c** {\n}</code></pre>",
+		},
+		{
+			name: "quote and rule",
+			in:   "> quoted\n> more\n\n---",
+			want: "<blockquote>quoted<br/>more</blockquote><hr/>",
+		},
+		{
+			name: "snake_case is not italic",
+			in:   "set max_retry_count",
+			want: "<p>set max_retry_count</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := markdownToHTML(tt.in); got != tt.want {
+				t.Fatalf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,87 +2,65 @@ package adoworkitem
 
 import (
 	"fmt"
-	"net/url"
 	"strconv"
 	"strings"
+
+	"github.com/krubenok/toolbox/internal/ado"
 )
 
 // ParsedWorkItem contains the extracted components from an Azure DevOps work item URL.
 type ParsedWorkItem struct {
-	Organization string
+	BaseURL      string // API base URL, e.g. https://dev.azure.com
+	Organization string // Organization, or collection on Azure DevOps Server
 	Project      string
 	ID           int
 }
 
 // ParseWorkItemURL parses an Azure DevOps work item URL and extracts its components.
-// Supports both dev.azure.com and *.visualstudio.com formats.
+// Supports dev.azure.com, *.visualstudio.com and Azure DevOps Server hosts
+// configured in ~/.toolbox/ado.json.
 func ParseWorkItemURL(rawURL string) (*ParsedWorkItem, error) {
-	u, err := url.Parse(rawURL)
-	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %s", rawURL)
-	}
-
-	parts := splitPath(u.Path)
-	host := strings.ToLower(u.Host)
-
-	if host == "dev.azure.com" {
-		return parseDevAzureCom(parts)
-	}
-
-	if strings.HasSuffix(host, ".visualstudio.com") {
-		org := strings.Split(host, ".")[0]
-		return parseVisualStudioCom(parts, org)
-	}
-
-	return nil, fmt.Errorf("unsupported Azure DevOps host: %s", host)
-}
-
-// splitPath splits a URL path into decoded segments.
-func splitPath(path string) []string {
-	var parts []string
-	for _, p := range strings.Split(path, "/") {
-		if p == "" {
-			continue
-		}
-		decoded, err := url.PathUnescape(p)
-		if err != nil {
-			decoded = p
-		}
-		parts = append(parts, decoded)
-	}
-	return parts
-}
-
-// parseDevAzureCom parses dev.azure.com/{org}/{project}/_workitems/edit/{id}
-func parseDevAzureCom(parts []string) (*ParsedWorkItem, error) {
-	// Expected: [org, project, _workitems, edit, id]
-	if len(parts) < 5 || parts[2] != "_workitems" || parts[3] != "edit" {
-		return nil, fmt.Errorf("work item URL path does not match expected dev.azure.com format")
-	}
-	id, err := strconv.Atoi(parts[4])
+	server, parts, err := ado.ParseURL(rawURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid work item id: %s", parts[4])
+		return nil, err
 	}
-	return &ParsedWorkItem{
-		Organization: parts[0],
-		Project:      parts[1],
-		ID:           id,
-	}, nil
-}
 
-// parseVisualStudioCom parses {org}.visualstudio.com/{project}/_workitems/edit/{id}
-func parseVisualStudioCom(parts []string, org string) (*ParsedWorkItem, error) {
 	// Expected: [project, _workitems, edit, id]
 	if len(parts) < 4 || parts[1] != "_workitems" || parts[2] != "edit" {
-		return nil, fmt.Errorf("work item URL path does not match expected {org}.visualstudio.com format")
+		return nil, fmt.Errorf("work item URL path does not match expected {org}/{project}/_workitems/edit/{id} format")
 	}
 	id, err := strconv.Atoi(parts[3])
 	if err != nil {
 		return nil, fmt.Errorf("invalid work item id: %s", parts[3])
 	}
 	return &ParsedWorkItem{
-		Organization: org,
+		BaseURL:      server.BaseURL,
+		Organization: server.Organization,
 		Project:      parts[0],
 		ID:           id,
 	}, nil
 }
+
+// ParsedProject contains the organization and project from an Azure DevOps URL.
+type ParsedProject struct {
+	BaseURL      string // API base URL, e.g. https://dev.azure.com
+	Organization string // Organization, or collection on Azure DevOps Server
+	Project      string
+}
+
+// ParseProjectURL extracts the organization and project from any Azure DevOps URL
+// that includes a project segment (project home, work item, board, or PR URLs).
+// Supports dev.azure.com, *.visualstudio.com and Azure DevOps Server hosts
+// configured in ~/.toolbox/ado.json.
+func ParseProjectURL(rawURL string) (*ParsedProject, error) {
+	server, parts, err := ado.ParseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expected: [project, ...]
+	if len(parts) < 1 || strings.HasPrefix(parts[0], "_") {
+		return nil, fmt.Errorf("project URL path does not match expected {org}/{project} format")
+	}
+	return &ParsedProject{BaseURL: server.BaseURL, Organization: server.Organization, Project: parts[0]}, nil
+}
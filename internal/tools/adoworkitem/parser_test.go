@@ -15,6 +15,7 @@ func TestParseWorkItemURL(t *testing.T) {
 			name:   "dev.azure.com format",
 			rawURL: "https://dev.azure.com/org/project/_workitems/edit/1144734",
 			want: &ParsedWorkItem{
+				BaseURL:      "https://dev.azure.com",
 				Organization: "org",
 				Project:      "project",
 				ID:           1144734,
@@ -24,6 +25,7 @@ func TestParseWorkItemURL(t *testing.T) {
 			name:   "visualstudio.com format",
 			rawURL: "https://org.visualstudio.com/project/_workitems/edit/1144734",
 			want: &ParsedWorkItem{
+				BaseURL:      "https://dev.azure.com",
 				Organization: "org",
 				Project:      "project",
 				ID:           1144734,
@@ -33,6 +35,7 @@ func TestParseWorkItemURL(t *testing.T) {
 			name:   "path-unescapes segments",
 			rawURL: "https://dev.azure.com/org/my%20project/_workitems/edit/1144734",
 			want: &ParsedWorkItem{
+				BaseURL:      "https://dev.azure.com",
 				Organization: "org",
 				Project:      "my project",
 				ID:           1144734,
@@ -79,3 +82,58 @@ func TestParseWorkItemURL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseProjectURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rawURL  string
+		want    *ParsedProject
+		wantErr bool
+	}{
+		{
+			name:   "dev.azure.com project home",
+			rawURL: "https://dev.azure.com/org/project",
+			want:   &ParsedProject{BaseURL: "https://dev.azure.com", Organization: "org", Project: "project"},
+		},
+		{
+			name:   "dev.azure.com work item URL",
+			rawURL: "https://dev.azure.com/org/my%20project/_workitems/edit/1",
+			want:   &ParsedProject{BaseURL: "https://dev.azure.com", Organization: "org", Project: "my project"},
+		},
+		{
+			name:   "visualstudio.com format",
+			rawURL: "https://org.visualstudio.com/project/_boards",
+			want:   &ParsedProject{BaseURL: "https://dev.azure.com", Organization: "org", Project: "project"},
+		},
+		{
+			name:    "missing project",
+			rawURL:  "https://dev.azure.com/org/_settings",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported host",
+			rawURL:  "https://example.com/org/project",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseProjectURL(tt.rawURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProjectURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != *tt.want {
+				t.Fatalf("ParseProjectURL() got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
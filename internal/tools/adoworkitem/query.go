@@ -0,0 +1,171 @@
+package adoworkitem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// defaultQueryTop is the default maximum number of work items returned by a WIQL query.
+	defaultQueryTop = 100
+	// maxQueryTop caps how many work items a single query may return.
+	maxQueryTop = 1000
+)
+
+// queryFields are the fields fetched for the compact query table.
+var queryFields = []string{
+	"System.Id",
+	"System.WorkItemType",
+	"System.State",
+	"System.AssignedTo",
+	"System.Title",
+}
+
+// QueryOptions configures a WIQL work item query.
+type QueryOptions struct {
+	Ctx        context.Context
+	ProjectURL string // Any ADO URL identifying the organization and project
+	Team       string // Optional team, required for team macros such as @CurrentIteration
+	Query      string // WIQL query text
+	Top        int    // Maximum number of work items (0 = default of 100)
+	Expand     bool   // Return full SimplifiedWorkItem entries instead of the compact table
+
+	OutputJSON bool
+	Debug      bool
+	DebugLog   func(string)
+}
+
+// QueryResult contains the output from a WIQL query.
+type QueryResult struct {
+	Rows      []WorkItemRow        // Compact rows (when not expanded)
+	WorkItems []SimplifiedWorkItem // Full work items (when expanded)
+	Output    string
+}
+
+// WorkItemRow is a compact, table-friendly view of a work item.
+type WorkItemRow struct {
+	ID         int    `json:"id"`
+	Type       string `json:"type"`
+	State      string `json:"state"`
+	AssignedTo string `json:"assignedTo"`
+	Title      string `json:"title"`
+}
+
+// Query runs a WIQL query and batch-fetches the matching work items.
+func Query(opts QueryOptions) (*QueryResult, error) {
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if strings.TrimSpace(opts.Query) == "" {
+		return nil, errors.New("query is required")
+	}
+	top := opts.Top
+	if top <= 0 {
+		top = defaultQueryTop
+	}
+	if top > maxQueryTop {
+		top = maxQueryTop
+	}
+
+	project, err := ParseProjectURL(opts.ProjectURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := LoadConfig(project.Organization)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	client, err := newRunClient(project.BaseURL, project.Organization, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+
+	wiql, err := client.RunWIQL(ctx, project.Organization, project.Project, opts.Team, opts.Query, top)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := wiql.IDs()
+	if len(ids) > top {
+		ids = ids[:top]
+	}
+
+	result := &QueryResult{}
+
+	if !opts.Expand {
+		items, err := client.FetchWorkItemsBatch(ctx, project.Organization, project.Project, ids, queryFields)
+		if err != nil {
+			return nil, err
+		}
+		result.Rows = make([]WorkItemRow, 0, len(items))
+		for _, wi := range items {
+			result.Rows = append(result.Rows, SimplifyWorkItemRow(wi))
+		}
+		maps := make([]map[string]any, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			maps = append(maps, WorkItemRowToMap(row))
+		}
+		result.Output, err = marshalOutput(result.Rows, maps, opts.OutputJSON, opts.Debug, opts.DebugLog)
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	items, err := client.FetchWorkItemsBatch(ctx, project.Organization, project.Project, ids, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.WorkItems = expandWorkItems(project, items, cfg, client.BaseURL())
+	maps := make([]map[string]any, 0, len(result.WorkItems))
+	for _, wi := range result.WorkItems {
+		maps = append(maps, WorkItemToMap(wi, cfg.Output))
+	}
+	result.Output, err = marshalOutput(result.WorkItems, maps, opts.OutputJSON, opts.Debug, opts.DebugLog)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// expandWorkItems simplifies batch-fetched work items the way ado-work-item does,
+// including the extra fields listed in the config.
+func expandWorkItems(project *ParsedProject, items []WorkItemResponse, cfg *Config, baseURL string) []SimplifiedWorkItem {
+	out := make([]SimplifiedWorkItem, 0, len(items))
+	for _, wi := range items {
+		parsed := &ParsedWorkItem{Organization: project.Organization, Project: project.Project, ID: wi.ID}
+		simplified := SimplifyWorkItem(parsed, wi, nil, baseURL)
+		simplified.Fields = selectFields(wi.Fields, mergeFieldNames(cfg.Fields))
+		out = append(out, simplified)
+	}
+	return out
+}
+
+// SimplifyWorkItemRow converts a raw work item to a compact row.
+func SimplifyWorkItemRow(wi WorkItemResponse) WorkItemRow {
+	return WorkItemRow{
+		ID:         wi.ID,
+		Type:       getStringField(wi.Fields, "System.WorkItemType"),
+		State:      getStringField(wi.Fields, "System.State"),
+		AssignedTo: getIdentityDisplayName(wi.Fields, "System.AssignedTo"),
+		Title:      getStringField(wi.Fields, "System.Title"),
+	}
+}
+
+// WorkItemRowToMap converts a WorkItemRow to a map for TOON output.
+// Every column is always present so rows render as a single compact table.
+func WorkItemRowToMap(r WorkItemRow) map[string]any {
+	return map[string]any{
+		"id":         r.ID,
+		"type":       r.Type,
+		"state":      r.State,
+		"assignedTo": r.AssignedTo,
+		"title":      r.Title,
+	}
+}
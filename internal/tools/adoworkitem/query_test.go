@@ -0,0 +1,118 @@
+package adoworkitem
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func TestWIQLResponseIDs(t *testing.T) {
+	t.Parallel()
+
+	resp := WIQLResponse{
+		WorkItemRelations: []WorkItemLinkRelation{
+			{Target: &WorkItemReference{ID: 10}},
+			{Rel: "System.LinkTypes.Hierarchy-Forward", Source: &WorkItemReference{ID: 10}, Target: &WorkItemReference{ID: 11}},
+			{Rel: "System.LinkTypes.Hierarchy-Forward", Source: &WorkItemReference{ID: 10}, Target: &WorkItemReference{ID: 12}},
+		},
+	}
+	if got, want := resp.IDs(), []int{10, 11, 12}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestClientRunWIQLAndBatch(t *testing.T) {
+	t.Parallel()
+
+	api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
+	client := NewClient(api)
+
+	var batchIDs []int
+	api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		var payload any
+		switch {
+		case r.URL.Path == "/org/project/team/_apis/wit/wiql":
+			if r.Method != http.MethodPost {
+				t.Fatalf("method = %s, want POST", r.Method)
+			}
+			payload = WIQLResponse{WorkItems: []WorkItemReference{{ID: 2}, {ID: 3}, {ID: 1}}}
+		case r.URL.Path == "/org/project/_apis/wit/workitemsbatch":
+			var body workItemsBatchRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode batch body: %v", err)
+			}
+			if body.ErrorPolicy != "omit" {
+				t.Fatalf("errorPolicy = %q, want omit", body.ErrorPolicy)
+			}
+			batchIDs = body.IDs
+			// Work item 3 was deleted, so it comes back as null.
+			payload = workItemsBatchResponse{Value: []*WorkItemResponse{
+				{ID: 1, Fields: map[string]any{"System.Title": "One", "System.State": "New"}},
+				nil,
+				{ID: 2, Fields: map[string]any{"System.Title": "Two", "System.AssignedTo": map[string]any{"displayName": "Jane"}}},
+			}}
+		default:
+			t.Fatalf("unexpected request: %s", r.URL)
+		}
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(string(b))),
+			Request:    r,
+		}, nil
+	}))
+
+	wiql, err := client.RunWIQL(context.Background(), "org", "project", "team", "SELECT [System.Id] FROM WorkItems", 10)
+	if err != nil {
+		t.Fatalf("RunWIQL: %v", err)
+	}
+	items, err := client.FetchWorkItemsBatch(context.Background(), "org", "project", wiql.IDs(), queryFields)
+	if err != nil {
+		t.Fatalf("FetchWorkItemsBatch: %v", err)
+	}
+	if !reflect.DeepEqual(batchIDs, []int{2, 3, 1}) {
+		t.Fatalf("batch ids = %v, want [2 3 1]", batchIDs)
+	}
+	if len(items) != 2 || items[0].ID != 2 || items[1].ID != 1 {
+		t.Fatalf("items not in query order: %+v", items)
+	}
+
+	row := SimplifyWorkItemRow(items[0])
+	if row.Title != "Two" || row.AssignedTo != "Jane" {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+}
+
+func TestExpandWorkItemsSelectsConfiguredFields(t *testing.T) {
+	t.Parallel()
+
+	project := &ParsedProject{BaseURL: "https://dev.azure.com", Organization: "org", Project: "project"}
+	items := []WorkItemResponse{
+		{ID: 1, Fields: map[string]any{"System.Title": "One", "System.AreaPath": "project\\Web", "Custom.Team": "Blue"}},
+		{ID: 2, Fields: map[string]any{"System.Title": "Two"}},
+	}
+	cfg := &Config{Fields: []string{"System.AreaPath"}, Output: DefaultOutputConfig()}
+
+	got := expandWorkItems(project, items, cfg, "https://dev.azure.com")
+	if len(got) != 2 || got[0].Title != "One" || got[1].ID != 2 {
+		t.Fatalf("expandWorkItems = %+v", got)
+	}
+	if want := map[string]any{"System.AreaPath": "project\\Web"}; !reflect.DeepEqual(got[0].Fields, want) {
+		t.Fatalf("fields = %v, want %v", got[0].Fields, want)
+	}
+	if got[1].Fields != nil {
+		t.Fatalf("fields = %v, want none", got[1].Fields)
+	}
+}
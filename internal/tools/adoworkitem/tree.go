@@ -0,0 +1,107 @@
+package adoworkitem
+
+import (
+	"context"
+)
+
+const (
+	// maxChildDepth caps how many hierarchy levels are walked below the root work item.
+	maxChildDepth = 10
+	// defaultMaxTreeNodes caps how many descendants are fetched for one work item.
+	defaultMaxTreeNodes = 500
+)
+
+// FetchChildTree walks Hierarchy-Forward relations below root up to depth levels and
+// returns the resolved children as a nested tree. Each level is fetched with a single
+// batch request. Work items already seen (cycles, or items reachable by more than one
+// path) are only included once, at the shallowest level. If more than maxNodes
+// descendants exist, the tree is truncated and the second return value is true.
+func (c *Client) FetchChildTree(ctx context.Context, parsed *ParsedWorkItem, root WorkItemResponse, depth, maxNodes int) ([]SimplifiedChildLink, bool, error) {
+	if depth <= 0 {
+		return extractChildren(c.BaseURL(), parsed.Organization, parsed.Project, root.Relations), false, nil
+	}
+	if depth > maxChildDepth {
+		depth = maxChildDepth
+	}
+	if maxNodes <= 0 {
+		maxNodes = defaultMaxTreeNodes
+	}
+
+	visited := map[int]bool{root.ID: true}
+	fetched := make(map[int]WorkItemResponse)
+	edges := make(map[int][]int)
+	truncated := false
+
+	// nextLevel records unseen children of parent and returns them in relation order.
+	nextLevel := func(parent WorkItemResponse) []int {
+		var ids []int
+		for _, r := range parent.Relations {
+			if !isChildRelation(r.Rel) {
+				continue
+			}
+			id := extractWorkItemIDFromRelationURL(r.URL)
+			if id == 0 || visited[id] {
+				continue
+			}
+			visited[id] = true
+			edges[parent.ID] = append(edges[parent.ID], id)
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	level := nextLevel(root)
+	for d := 1; d <= depth && len(level) > 0; d++ {
+		if remaining := maxNodes - len(fetched); len(level) > remaining {
+			level = level[:remaining]
+			truncated = true
+		}
+		if len(level) == 0 {
+			break
+		}
+
+		c.api.Debugf("Fetching %d work items at depth %d", len(level), d)
+		items, err := c.FetchWorkItemsBatch(ctx, parsed.Organization, parsed.Project, level, nil)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, wi := range items {
+			fetched[wi.ID] = wi
+		}
+
+		if d == depth {
+			break
+		}
+		var next []int
+		for _, id := range level {
+			if wi, ok := fetched[id]; ok {
+				next = append(next, nextLevel(wi)...)
+			}
+		}
+		level = next
+	}
+
+	return buildChildTree(c.BaseURL(), parsed, root.ID, edges, fetched), truncated, nil
+}
+
+// buildChildTree assembles the nested children of id from the fetched work items.
+func buildChildTree(baseURL string, parsed *ParsedWorkItem, id int, edges map[int][]int, fetched map[int]WorkItemResponse) []SimplifiedChildLink {
+	children := make([]SimplifiedChildLink, 0, len(edges[id]))
+	for _, childID := range edges[id] {
+		wi, ok := fetched[childID]
+		if !ok {
+			continue
+		}
+		children = append(children, SimplifiedChildLink{
+			ID:         wi.ID,
+			URL:        wi.URL,
+			UIURL:      UIWorkItemURL(baseURL, parsed.Organization, parsed.Project, wi.ID),
+			Title:      getStringField(wi.Fields, "System.Title"),
+			Type:       getStringField(wi.Fields, "System.WorkItemType"),
+			State:      getStringField(wi.Fields, "System.State"),
+			AssignedTo: getIdentityDisplayName(wi.Fields, "System.AssignedTo"),
+			Children:   buildChildTree(baseURL, parsed, wi.ID, edges, fetched),
+		})
+	}
+	return children
+}
@@ -0,0 +1,128 @@
+package adoworkitem
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/krubenok/toolbox/internal/ado"
+	"github.com/krubenok/toolbox/internal/auth"
+)
+
+func childRel(id int) WorkItemRelation {
+	return WorkItemRelation{
+		Rel: "System.LinkTypes.Hierarchy-Forward",
+		URL: "https://dev.azure.com/org/_apis/wit/workItems/" + strconv.Itoa(id),
+	}
+}
+
+func TestFetchChildTree(t *testing.T) {
+	t.Parallel()
+
+	// 1 -> 2, 3; 2 -> 4, 1 (cycle); 3 -> 4 (diamond); 4 -> 5
+	items := map[int]WorkItemResponse{
+		2: {ID: 2, Fields: map[string]any{"System.Title": "Two", "System.State": "Active"}, Relations: []WorkItemRelation{childRel(4), childRel(1)}},
+		3: {ID: 3, Fields: map[string]any{"System.Title": "Three"}, Relations: []WorkItemRelation{childRel(4)}},
+		4: {ID: 4, Fields: map[string]any{"System.Title": "Four"}, Relations: []WorkItemRelation{childRel(5)}},
+		5: {ID: 5, Fields: map[string]any{"System.Title": "Five"}},
+	}
+	root := WorkItemResponse{ID: 1, Relations: []WorkItemRelation{childRel(2), childRel(3)}}
+	parsed := &ParsedWorkItem{Organization: "org", Project: "project", ID: 1}
+
+	newClient := func(t *testing.T, requests *int) *Client {
+		api := ado.NewClient(&auth.Auth{Scheme: "Basic", Token: "dummy"}, "https://example.test", false, nil)
+		api.SetTransport(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			*requests++
+			var body workItemsBatchRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode batch body: %v", err)
+			}
+			var resp workItemsBatchResponse
+			for _, id := range body.IDs {
+				item := items[id]
+				resp.Value = append(resp.Value, &item)
+			}
+			b, _ := json.Marshal(resp)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Status:     "200 OK",
+				Body:       io.NopCloser(strings.NewReader(string(b))),
+				Request:    r,
+			}, nil
+		}))
+		return NewClient(api)
+	}
+
+	t.Run("walks levels once per depth and skips cycles", func(t *testing.T) {
+		t.Parallel()
+
+		requests := 0
+		client := newClient(t, &requests)
+		tree, truncated, err := client.FetchChildTree(context.Background(), parsed, root, 5, 0)
+		if err != nil {
+			t.Fatalf("FetchChildTree: %v", err)
+		}
+		if truncated {
+			t.Fatalf("unexpected truncation")
+		}
+		if requests != 3 {
+			t.Fatalf("requests = %d, want 3 (one per level)", requests)
+		}
+		if len(tree) != 2 || tree[0].ID != 2 || tree[0].Title != "Two" || tree[0].State != "Active" {
+			t.Fatalf("unexpected top level: %+v", tree)
+		}
+		if len(tree[0].Children) != 1 || tree[0].Children[0].ID != 4 {
+			t.Fatalf("item 2 children = %+v, want only 4 (1 is a cycle)", tree[0].Children)
+		}
+		if len(tree[1].Children) != 0 {
+			t.Fatalf("item 3 children = %+v, want none (4 already shown under 2)", tree[1].Children)
+		}
+		if grand := tree[0].Children[0].Children; len(grand) != 1 || grand[0].ID != 5 {
+			t.Fatalf("item 4 children = %+v, want 5", grand)
+		}
+	})
+
+	t.Run("respects depth", func(t *testing.T) {
+		t.Parallel()
+
+		requests := 0
+		client := newClient(t, &requests)
+		tree, _, err := client.FetchChildTree(context.Background(), parsed, root, 1, 0)
+		if err != nil {
+			t.Fatalf("FetchChildTree: %v", err)
+		}
+		if requests != 1 || len(tree) != 2 || len(tree[0].Children) != 0 {
+			t.Fatalf("requests=%d tree=%+v, want one level", requests, tree)
+		}
+	})
+
+	t.Run("caps node count", func(t *testing.T) {
+		t.Parallel()
+
+		requests := 0
+		client := newClient(t, &requests)
+		tree, truncated, err := client.FetchChildTree(context.Background(), parsed, root, 5, 3)
+		if err != nil {
+			t.Fatalf("FetchChildTree: %v", err)
+		}
+		if !truncated {
+			t.Fatalf("expected truncation")
+		}
+		count := 0
+		var walk func([]SimplifiedChildLink)
+		walk = func(nodes []SimplifiedChildLink) {
+			for _, n := range nodes {
+				count++
+				walk(n.Children)
+			}
+		}
+		walk(tree)
+		if count != 3 {
+			t.Fatalf("node count = %d, want 3", count)
+		}
+	})
+}